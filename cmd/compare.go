@@ -0,0 +1,112 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pulumi/crd2pulumi/pkg/codegen"
+	"github.com/spf13/cobra"
+)
+
+const compareLong = `Compares the SDKs generated from two versions of the same CRDs and reports the
+changes that affect programs using them.
+
+Removed resources, types, properties and enum values, properties that became
+required, type changes and renamed modules are breaking. Added resources,
+types and properties are features, and description and default changes are
+patches. The report ends with the semver bump the new SDKs need; pass the
+version of the old SDKs with --version to get the next version.
+
+Pass the same filter, --version-policy, --group-module, --output-only and
+--immutable flags used to generate the code, so both SDKs are compared as
+they are generated.`
+
+const compareExample = `crd2pulumi compare cert-manager-v1.14.crds.yaml cert-manager-v1.15.crds.yaml
+crd2pulumi compare --version 1.4.2 --format json old/crontabs.yaml crontabs.yaml`
+
+func newCompareCommand() *cobra.Command {
+	var format string
+
+	compareCmd := &cobra.Command{
+		Use:          "compare <old.yaml> <new.yaml>",
+		Short:        "Report breaking changes between two versions of CRDs",
+		Long:         compareLong,
+		Example:      compareExample,
+		SilenceUsage: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return errors.New("must specify the old and new CRD YAML files")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unsupported format %q, must be text or json", format)
+			}
+			packageVersion, _ := cmd.Flags().GetString("version")
+			if !cmd.Flags().Changed("version") {
+				packageVersion = ""
+			}
+
+			pgs := make([]*codegen.PackageGenerator, len(args))
+			for i, path := range args {
+				sources, err := codegen.OpenSources([]string{path})
+				if err != nil {
+					return fmt.Errorf("error comparing CRDs: %w", err)
+				}
+				pgs[i], err = codegen.ReadPackagesFromSource(packageVersion, sources, packageOptions(cmd)...)
+				if err != nil {
+					return fmt.Errorf("error reading %s: %w", path, err)
+				}
+			}
+			report, err := codegen.ComparePackages(pgs[0], pgs[1], packageVersion)
+			if err != nil {
+				return fmt.Errorf("error comparing CRDs: %w", err)
+			}
+
+			if format == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			for _, change := range report.Changes {
+				subject := change.Token
+				if change.Property != "" {
+					subject += "." + change.Property
+				}
+				if subject != "" {
+					subject += ": "
+				}
+				fmt.Printf("%-9s %s%s\n", change.Level, subject, change.Message)
+			}
+			switch {
+			case report.Bump == "none":
+				fmt.Println("No changes to the generated SDKs.")
+			case report.Version != "":
+				fmt.Printf("Suggested bump: %s (--version %s)\n", report.Bump, report.Version)
+			default:
+				fmt.Printf("Suggested bump: %s\n", report.Bump)
+			}
+			return nil
+		},
+	}
+
+	compareCmd.Flags().StringVarP(&format, "format", "", "text", "format of the report (text or json)")
+	return compareCmd
+}
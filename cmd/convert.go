@@ -0,0 +1,106 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pulumi/crd2pulumi/pkg/codegen"
+	"github.com/spf13/cobra"
+)
+
+const convertLong = `Converts custom resource manifests into a Pulumi program that creates the
+same resources with the code generated from their CRDs.
+
+Manifests are matched to the CRDs by their apiVersion and kind, and their
+fields are written with the property names of the chosen language. Other
+manifests, fields that aren't in the CRD schemas and values of the wrong type
+are left out and reported as warnings. Fields set by the API server, such as
+metadata.uid and status, are dropped. So is the namespace of cluster-scoped
+kinds, with a warning.
+
+Pass the same filter, --version-policy, --group-module, --output-only and
+--immutable flags used to generate the code, so the program refers to the
+same resources, modules and properties.`
+
+const convertExample = `crd2pulumi convert --crds certificates.crds.yaml certificate.yaml
+crd2pulumi convert --crds crontabs.yaml --language python --sdk crontabs.pulumi_crds -o __main__.py crontab.yaml
+crd2pulumi convert --crds crontabs.yaml --language go --sdk example.com/infra/crds -o main.go manifests/*.yaml`
+
+func newConvertCommand() *cobra.Command {
+	var crdPaths []string
+	var outputPath string
+	var settings codegen.ConvertSettings
+
+	convertCmd := &cobra.Command{
+		Use:          "convert --crds <crd.yaml> [--language nodejs|python|go|yaml] <manifest.yaml> [manifest.yaml ...]",
+		Short:        "Convert custom resource manifests into a Pulumi program",
+		Long:         convertLong,
+		Example:      convertExample,
+		SilenceUsage: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+				return errors.New("must specify at least one manifest")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(crdPaths) == 0 {
+				return errors.New("must specify the CRDs of the manifests with --crds")
+			}
+			packageVersion, _ := cmd.Flags().GetString("version")
+
+			crds, err := codegen.OpenSources(crdPaths)
+			if err != nil {
+				return fmt.Errorf("error converting manifests: %w", err)
+			}
+			pg, err := codegen.ReadPackagesFromSource(packageVersion, crds, packageOptions(cmd)...)
+			if err != nil {
+				return fmt.Errorf("error converting manifests: %w", err)
+			}
+			manifests, err := codegen.OpenSources(args)
+			if err != nil {
+				return fmt.Errorf("error converting manifests: %w", err)
+			}
+			program, warnings, err := codegen.Convert(pg, settings, manifests)
+			for _, warning := range warnings {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+			}
+			if err != nil {
+				return fmt.Errorf("error converting manifests: %w", err)
+			}
+
+			if outputPath == "" {
+				_, err = os.Stdout.Write(program)
+				return err
+			}
+			if err := os.WriteFile(outputPath, program, 0o644); err != nil {
+				return fmt.Errorf("error writing program: %w", err)
+			}
+			fmt.Printf("Successfully converted %d manifest files into %s.\n", len(args), outputPath)
+			return nil
+		},
+	}
+
+	f := convertCmd.Flags()
+	f.StringSliceVarP(&crdPaths, "crds", "", nil, "CRD YAML file or URL defining the custom resources (repeatable)")
+	f.StringVarP(&settings.Language, "language", "", codegen.NodeJS, "language of the program (nodejs, python, go or yaml)")
+	f.StringVarP(&settings.PackageName, "name", "", codegen.DefaultName, "name of the generated package")
+	f.StringVarP(&settings.SDK, "sdk", "", "", "module or import path of the generated package (default ./<name>, pulumi_<name> or <name>)")
+	f.StringVarP(&outputPath, "out", "o", "", "file to write the program to instead of stdout")
+	return convertCmd
+}
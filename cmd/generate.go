@@ -0,0 +1,130 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"maps"
+
+	"github.com/pulumi/crd2pulumi/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const generateLong = `Generates the languages configured in a crd2pulumi project configuration file.
+
+The configuration file captures the CRD sources and the settings of each
+language, so that regenerating the code doesn't depend on long command lines:
+
+  version: 1.2.3
+  sources:
+    - crds/certificates.yaml
+    - https://example.com/issuers.yaml
+  filters:
+    excludeKinds: [Challenge]
+  groupModules:
+    acme.cert-manager.io: acme
+  languages:
+    nodejs:
+      name: certmanager
+      outputDir: sdk/nodejs
+    python: {}
+
+Relative paths are resolved against the directory containing the file. The
+flags that select and shape the generated SDK, such as --include-group or
+--version, override the settings of the file when they are set.`
+
+func newGenerateCommand() *cobra.Command {
+	var configPath string
+	var flags generationFlags
+
+	generateCmd := &cobra.Command{
+		Use:          "generate [-c crd2pulumi.yaml]",
+		Short:        "Generate code from a crd2pulumi.yaml project configuration file",
+		Long:         generateLong,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				return err
+			}
+			applyPackageFlags(cmd, cfg)
+
+			settings := cfg.Settings()
+			for _, cs := range settings {
+				cs.Overwrite = cs.Overwrite || flags.force
+				cs.Prune = cs.Prune || flags.prune
+				cs.ValidationHelpers = cs.ValidationHelpers || flags.validationHelpers
+			}
+
+			var documents [][]byte
+			if cfg.Cluster != nil {
+				documents, err = readCRDsFromCluster(cmd.Context(), cfg.ClusterOptions())
+				if err != nil {
+					return err
+				}
+			}
+			output := flags.output
+			output.strict = output.strict || cfg.Strict
+			cfg.Strict = output.strict
+			cfg.LookupFunctions = cfg.LookupFunctions || flags.lookupFunctions
+			return generate(settings, documents, cfg.SourcePaths(), output, cfg.PackageOptions()...)
+		},
+	}
+
+	generateCmd.Flags().StringVarP(&configPath, "config", "c", config.DefaultFileName, "path to the project configuration file")
+	flags.register(generateCmd)
+	return generateCmd
+}
+
+// applyPackageFlags overrides the settings of `cfg` with the persistent flags that select and shape the generated SDK,
+// where they are set on the command line, so that they aren't silently ignored. Group modules are merged, and
+// --version also replaces the versions of the individual languages.
+func applyPackageFlags(cmd *cobra.Command, cfg *config.Config) {
+	f := cmd.Flags()
+	if cfg.Filters == nil {
+		cfg.Filters = &config.Filters{}
+	}
+	stringSlices := map[string]*[]string{
+		"include-group": &cfg.Filters.IncludeGroups,
+		"exclude-group": &cfg.Filters.ExcludeGroups,
+		"include-kind":  &cfg.Filters.IncludeKinds,
+		"exclude-kind":  &cfg.Filters.ExcludeKinds,
+		"versions":      &cfg.Filters.Versions,
+		"output-only":   &cfg.OutputOnly,
+		"immutable":     &cfg.Immutable,
+	}
+	for name, value := range stringSlices {
+		if f.Changed(name) {
+			*value, _ = f.GetStringSlice(name)
+		}
+	}
+	if f.Changed("version-policy") {
+		cfg.Filters.VersionPolicy, _ = f.GetString("version-policy")
+	}
+	if f.Changed("group-module") {
+		groupModules, _ := f.GetStringToString("group-module")
+		if cfg.GroupModules == nil {
+			cfg.GroupModules = map[string]string{}
+		}
+		maps.Copy(cfg.GroupModules, groupModules)
+	}
+	if f.Changed("version") {
+		cfg.Version, _ = f.GetString("version")
+		for name, language := range cfg.Languages {
+			language.Version = ""
+			cfg.Languages[name] = language
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pulumi/crd2pulumi/internal/config"
+)
+
+const testConfig = `
+version: 1.0.0
+sources: [crds.yaml]
+filters:
+  includeGroups: [cert-manager.io]
+  excludeKinds: [Challenge]
+groupModules:
+  acme.cert-manager.io: acme
+languages:
+  nodejs:
+    version: 1.1.0
+  python: {}`
+
+func TestApplyPackageFlags(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantGroups   []string
+		wantModules  map[string]string
+		wantVersions []string
+	}{
+		{
+			name:         "No flags",
+			wantGroups:   []string{"cert-manager.io"},
+			wantModules:  map[string]string{"acme.cert-manager.io": "acme"},
+			wantVersions: []string{"1.1.0", "1.0.0"},
+		},
+		{
+			name:         "Flags",
+			args:         []string{"--include-group", "*.example.com", "--group-module", "networking.gke.io=gke", "--version", "2.0.0"},
+			wantGroups:   []string{"*.example.com"},
+			wantModules:  map[string]string{"acme.cert-manager.io": "acme", "networking.gke.io": "gke"},
+			wantVersions: []string{"2.0.0", "2.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generateCmd, _, err := New().Find([]string{"generate"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := generateCmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cfg, err := config.Parse([]byte(testConfig))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			applyPackageFlags(generateCmd, cfg)
+
+			if got := cfg.Filter().IncludeGroups; !reflect.DeepEqual(got, tt.wantGroups) {
+				t.Errorf("expected included groups %v, got %v", tt.wantGroups, got)
+			}
+			if got := cfg.Filter().ExcludeKinds; !reflect.DeepEqual(got, []string{"Challenge"}) {
+				t.Errorf("expected the excluded kinds of the file to be kept, got %v", got)
+			}
+			if !reflect.DeepEqual(cfg.GroupModules, tt.wantModules) {
+				t.Errorf("expected group modules %v, got %v", tt.wantModules, cfg.GroupModules)
+			}
+			var versions []string
+			for _, cs := range cfg.Settings() {
+				versions = append(versions, cs.PackageVersion)
+			}
+			if !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("expected versions %v, got %v", tt.wantVersions, versions)
+			}
+		})
+	}
+}
@@ -16,11 +16,13 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 
+	"github.com/pulumi/crd2pulumi/internal/cluster"
 	"github.com/pulumi/crd2pulumi/pkg/codegen"
 	"github.com/spf13/cobra"
 )
@@ -36,6 +38,17 @@ const example = `crd2pulumi --nodejs crontabs.yaml
 crd2pulumi -dgnp crd-certificates.yaml crd-issuers.yaml crd-challenges.yaml
 crd2pulumi --pythonPath=crds/python/istio --nodejsPath=crds/nodejs/istio crd-all.gen.yaml crd-mixer.yaml crd-operator.yaml
 crd2pulumi --pythonPath=crds/python/gke https://raw.githubusercontent.com/GoogleCloudPlatform/gke-managed-certs/master/deploy/managedcertificates-crd.yaml
+crd2pulumi --nodejs --from-cluster --context=staging --include-group='*.cert-manager.io'
+crd2pulumi --schemaPath=crds/schema --schemaFormat=yaml crd-certificates.yaml
+crd2pulumi --docsPath=crds/docs --docsFormat=html crd-certificates.yaml
+crd2pulumi --yaml crd-certificates.yaml
+crd2pulumi --nodejs --include-group='*.cert-manager.io' --exclude-kind=Challenge --versions=v1 cert-manager.crds.yaml
+crd2pulumi --nodejs --version-policy=served cert-manager.crds.yaml
+crd2pulumi --nodejs --diff crontabs.yaml
+crd2pulumi generate -c crd2pulumi.yaml
+crd2pulumi compare --version 1.4.2 old/crontabs.yaml crontabs.yaml
+crd2pulumi convert --crds crontabs.yaml --language python crontab.yaml
+crd2pulumi validate crontabs.yaml crontab.yaml
 
 Notice that by just setting a language-specific output path (--pythonPath, --nodejsPath, etc) the code will
 still get generated, so setting -p, -n, etc becomes unnecessary.
@@ -47,10 +60,19 @@ func New() *cobra.Command {
 	nodejsSettings := &codegen.CodegenSettings{Language: "nodejs"}
 	pythonSettings := &codegen.CodegenSettings{Language: "python"}
 	javaSettings := &codegen.CodegenSettings{Language: "java"}
-	allSettings := []*codegen.CodegenSettings{dotNetSettings, goSettings, nodejsSettings, pythonSettings, javaSettings}
+	schemaSettings := &codegen.CodegenSettings{Language: "schema", PackageName: codegen.DefaultName}
+	docsSettings := &codegen.CodegenSettings{Language: "docs", PackageName: codegen.DefaultName}
+	yamlSettings := &codegen.CodegenSettings{Language: "yaml", PackageName: codegen.DefaultName}
+	allSettings := []*codegen.CodegenSettings{dotNetSettings, goSettings, nodejsSettings, pythonSettings, javaSettings, schemaSettings, docsSettings, yamlSettings}
 
-	var force bool
+	var flags generationFlags
 	var packageVersion string
+	var fromCluster bool
+	var clusterOptions cluster.Options
+	var filter codegen.Filter
+	var groupModules map[string]string
+	var outputOnly []string
+	var immutable []string
 
 	rootCmd := &cobra.Command{
 		Use:          "crd2pulumi [-dgnp] [--nodejsPath path] [--pythonPath path] [--dotnetPath path] [--goPath path] <crd1.yaml> [crd2.yaml ...]",
@@ -58,7 +80,15 @@ func New() *cobra.Command {
 		Long:         long,
 		Example:      example,
 		SilenceUsage: true, // Don't show the usage message upon program error
+		// main prints errors, so that ErrChanges from --dry-run and --diff isn't reported as one.
+		SilenceErrors: true,
 		Args: func(cmd *cobra.Command, args []string) error {
+			if fromCluster {
+				if len(args) > 0 {
+					return errors.New("cannot specify CRD YAML files with --from-cluster")
+				}
+				return nil
+			}
 			if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
 				return errors.New("must specify at least one CRD YAML file")
 			}
@@ -66,9 +96,11 @@ func New() *cobra.Command {
 		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			for _, cs := range allSettings {
-				if force {
+				if flags.force {
 					cs.Overwrite = true
 				}
+				cs.Prune = flags.prune
+				cs.ValidationHelpers = flags.validationHelpers
 				if cs.OutputDir != "" {
 					cs.ShouldGenerate = true
 				}
@@ -77,33 +109,40 @@ func New() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var stdinData []byte
-			shouldUseStdin := len(args) == 1 && args[0] == "-"
-			if shouldUseStdin {
+			// documents holds the CRDs when they are not read from files.
+			var documents [][]byte
+			switch {
+			case fromCluster:
+				// The included groups are also selected when listing, so other CRDs aren't read at all.
+				clusterOptions.Groups = filter.IncludeGroups
 				var err error
-				stdinData, err = io.ReadAll(os.Stdin)
+				documents, err = readCRDsFromCluster(cmd.Context(), clusterOptions)
+				if err != nil {
+					return err
+				}
+			case len(args) == 1 && args[0] == "-":
+				stdinData, err := io.ReadAll(os.Stdin)
 				if err != nil {
 					return fmt.Errorf("failed reading CRDs from stdin: %w", err)
 				}
+				documents = [][]byte{stdinData}
 			}
+			var languageSettings []*codegen.CodegenSettings
 			for _, cs := range allSettings {
-				if !cs.ShouldGenerate {
-					continue
-				}
-				var err error
-				if shouldUseStdin {
-					err = codegen.Generate(cs, []io.ReadCloser{io.NopCloser(bytes.NewBuffer(stdinData))})
-				} else {
-					err = codegen.GenerateFromFiles(cs, args)
-				}
-				if err != nil {
-					return fmt.Errorf("error generating code: %w", err)
+				if cs.ShouldGenerate {
+					languageSettings = append(languageSettings, cs)
 				}
-				fmt.Printf("Successfully generated %s code.\n", cs.Language)
 			}
-			return nil
+			if len(languageSettings) == 0 {
+				return nil
+			}
+			return generate(languageSettings, documents, args, flags.output, codegen.WithFilter(filter), codegen.WithGroupModules(groupModules), codegen.WithOutputOnly(outputOnly), codegen.WithImmutable(immutable), codegen.WithLookupFunctions(flags.lookupFunctions), codegen.WithStrict(flags.output.strict))
 		},
 	}
+	rootCmd.AddCommand(newGenerateCommand())
+	rootCmd.AddCommand(newCompareCommand())
+	rootCmd.AddCommand(newConvertCommand())
+	rootCmd.AddCommand(newValidateCommand())
 	rootCmd.AddCommand(&cobra.Command{
 		Use:   "version",
 		Short: "Print the version number of crd2pulumi",
@@ -113,15 +152,35 @@ func New() *cobra.Command {
 		},
 	})
 
+	// The flags that select and shape the generated SDK are shared with the subcommands that read CRDs, so that they
+	// see the same SDK as the root command generates.
 	f := rootCmd.PersistentFlags()
-	f.BoolVarP(&force, "force", "f", false, "overwrite existing files")
 	f.StringVarP(&packageVersion, "version", "v", "0.0.0-dev", "version of the generated package")
+	f.StringSliceVarP(&filter.IncludeGroups, "include-group", "", nil, "only generate CRDs whose API group matches this glob (repeatable)")
+	f.StringSliceVarP(&filter.ExcludeGroups, "exclude-group", "", nil, "skip CRDs whose API group matches this glob (repeatable)")
+	f.StringSliceVarP(&filter.IncludeKinds, "include-kind", "", nil, "only generate CRDs whose kind matches this glob (repeatable)")
+	f.StringSliceVarP(&filter.ExcludeKinds, "exclude-kind", "", nil, "skip CRDs whose kind matches this glob (repeatable)")
+	f.StringSliceVarP(&filter.Versions, "versions", "", nil, "only generate these CRD versions, e.g. v1,v1beta1")
+	f.StringVarP((*string)(&filter.VersionPolicy), "version-policy", "", string(codegen.VersionPolicyAll), "CRD versions to generate (all, served, storage or latest-served)")
+	f.StringSliceVarP(&outputOnly, "output-only", "", codegen.DefaultOutputOnly, "top-level property, or <Kind>.<property>, that can be read but not set (repeatable)")
+	f.StringSliceVarP(&immutable, "immutable", "", nil, "property path, or <Kind>.<path>, that replaces the resource when changed; prefix with ! to keep a detected one updatable (repeatable)")
+	f.StringToStringVarP(&groupModules, "group-module", "", nil, "generate an API group into a module, e.g. networking.gke.io=gke (repeatable)")
+
+	// The other flags only apply to generating languages from the command line.
+	f = rootCmd.Flags()
+	flags.register(rootCmd)
+
+	f.BoolVarP(&fromCluster, "from-cluster", "", false, "read CRDs from the cluster selected by --kubeconfig and --context instead of YAML files")
+	f.StringVarP(&clusterOptions.Kubeconfig, "kubeconfig", "", "", "path to the kubeconfig file used with --from-cluster")
+	f.StringVarP(&clusterOptions.Context, "context", "", "", "kubeconfig context used with --from-cluster")
+	f.StringVarP(&clusterOptions.LabelSelector, "selector", "l", "", "label selector of the CRDs read with --from-cluster")
 
 	f.StringVarP(&dotNetSettings.PackageName, "dotnetName", "", codegen.DefaultName, "name of generated .NET package")
 	f.StringVarP(&goSettings.PackageName, "goName", "", codegen.DefaultName, "name of generated Go package")
 	f.StringVarP(&nodejsSettings.PackageName, "nodejsName", "", codegen.DefaultName, "name of generated NodeJS package")
 	f.StringVarP(&pythonSettings.PackageName, "pythonName", "", codegen.DefaultName, "name of generated Python package")
 	f.StringVarP(&javaSettings.PackageName, "javaName", "", codegen.DefaultName, "name of generated Java package")
+	f.StringVarP(&schemaSettings.PackageName, "schemaName", "", codegen.DefaultName, "name of the package in the generated Pulumi schema")
 
 	f.StringVarP(&dotNetSettings.PackageNamespace, "dotnetNamespace", "", "", "namespace of generated .NET package")
 	f.StringVarP(&nodejsSettings.PackageNamespace, "nodejsNamespace", "", "", "namespace of generated NodeJS package")
@@ -133,11 +192,209 @@ func New() *cobra.Command {
 	f.StringVarP(&nodejsSettings.OutputDir, "nodejsPath", "", "", "optional NodeJS output dir")
 	f.StringVarP(&pythonSettings.OutputDir, "pythonPath", "", "", "optional Python output dir")
 	f.StringVarP(&javaSettings.OutputDir, "javaPath", "", "", "optional Java output dir")
+	f.StringVarP(&schemaSettings.OutputDir, "schemaPath", "", "", "optional Pulumi schema output dir")
+	f.StringVarP(&docsSettings.OutputDir, "docsPath", "", "", "optional API reference docs output dir")
+	f.StringVarP(&yamlSettings.OutputDir, "yamlPath", "", "", "optional Pulumi YAML schema output dir")
+
+	f.StringVarP(&schemaSettings.SchemaFormat, "schemaFormat", "", codegen.SchemaFormatJSON, "format of generated Pulumi schema (json or yaml)")
+	f.StringVarP(&docsSettings.DocsFormat, "docsFormat", "", codegen.DocsFormatMarkdown, "format of generated API reference docs (markdown or html)")
 
 	f.BoolVarP(&dotNetSettings.ShouldGenerate, "dotnet", "d", false, "generate .NET")
 	f.BoolVarP(&goSettings.ShouldGenerate, "go", "g", false, "generate Go")
 	f.BoolVarP(&nodejsSettings.ShouldGenerate, "nodejs", "n", false, "generate NodeJS")
 	f.BoolVarP(&pythonSettings.ShouldGenerate, "python", "p", false, "generate Python")
 	f.BoolVarP(&javaSettings.ShouldGenerate, "java", "j", false, "generate Java")
+	f.BoolVarP(&schemaSettings.ShouldGenerate, "schema", "s", false, "generate Pulumi schema")
+	f.BoolVarP(&docsSettings.ShouldGenerate, "docs", "", false, "generate API reference docs")
+	f.BoolVarP(&yamlSettings.ShouldGenerate, "yaml", "y", false, "generate Pulumi YAML schema")
 	return rootCmd
 }
+
+// ErrChanges is returned by --dry-run and --diff when the generated code differs from the output directories.
+var ErrChanges = errors.New("generated code differs from the output directories")
+
+// generationFlags are the flags shared by the root and generate commands that control how the code is generated and
+// written.
+type generationFlags struct {
+	force             bool
+	prune             bool
+	validationHelpers bool
+	lookupFunctions   bool
+	output            outputOptions
+}
+
+// register adds the generation flags to the local flags of `cmd`.
+func (g *generationFlags) register(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.BoolVarP(&g.force, "force", "f", false, "overwrite existing files")
+	f.BoolVarP(&g.prune, "prune", "", false, "delete previously generated files that are no longer generated, keeping hand-written files")
+	f.BoolVarP(&g.output.dryRun, "dry-run", "", false, "list the files that would be added, changed or removed instead of writing them")
+	f.BoolVarP(&g.output.diff, "diff", "", false, "like --dry-run, and also print a unified diff of every file")
+	f.BoolVarP(&g.lookupFunctions, "lookup-functions", "", false, "add functions that read existing resources by name and namespace")
+	f.BoolVarP(&g.validationHelpers, "validation-helpers", "", false, "add a validation module that checks resource arguments against the CRD constraints (NodeJS, Python and Go)")
+	f.BoolVarP(&g.output.strict, "strict", "", false, "fail if any field of the CRDs can't be represented exactly in the generated code, and make cluster-scoped resources fail when a namespace is set")
+}
+
+// outputOptions controls whether the generated code is written to disk.
+type outputOptions struct {
+	// dryRun lists the files that would change instead of writing them.
+	dryRun bool
+	// diff is dryRun, also printing a unified diff of every file.
+	diff bool
+	// strict fails generation if any field of the CRDs can't be represented exactly.
+	strict bool
+}
+
+// generate generates every language in `settings` from the given YAML documents, or from the files and URLs in `paths`
+// if there are no documents. The CRDs and versions skipped by the filter in `opts`, and the fields that can't be
+// represented exactly, are reported. With --dry-run or --diff, the changes are printed instead of written, and ErrChanges is
+// returned if there are any.
+func generate(settings []*codegen.CodegenSettings, documents [][]byte, paths []string, output outputOptions, opts ...codegen.PackageOption) error {
+	dryRun := output.dryRun || output.diff
+	if dryRun {
+		// Nothing is written, so existing output directories are compared rather than overwritten.
+		for _, cs := range settings {
+			cs.Overwrite = true
+		}
+	}
+	if err := codegen.CheckSettings(settings); err != nil {
+		return fmt.Errorf("error generating code: %w", err)
+	}
+
+	yamls := readers(documents)
+	if documents == nil {
+		var err error
+		yamls, err = codegen.OpenSources(paths)
+		if err != nil {
+			return fmt.Errorf("error generating code: %w", err)
+		}
+	}
+	pg, err := codegen.ReadPackagesFromSource(settings[0].PackageVersion, yamls, opts...)
+	if err != nil {
+		return fmt.Errorf("error generating code: %w", err)
+	}
+	for _, skipped := range pg.Skipped {
+		fmt.Printf("Skipped %s\n", skipped)
+	}
+	diagnostics, err := pg.Diagnostics()
+	if err != nil {
+		return fmt.Errorf("error generating code: %w", err)
+	}
+	if err := printDiagnostics(diagnostics, output.strict); err != nil {
+		return fmt.Errorf("error generating code: %w", err)
+	}
+
+	if dryRun {
+		changes, err := codegen.DiffPackages(pg, settings)
+		if err != nil {
+			return fmt.Errorf("error generating code: %w", err)
+		}
+		return printChanges(changes, output.diff)
+	}
+
+	if err := codegen.GeneratePackages(pg, settings); err != nil {
+		return fmt.Errorf("error generating code: %w", err)
+	}
+	for _, cs := range settings {
+		if cs.Language == codegen.Schema {
+			fmt.Printf("Successfully generated Pulumi schema.\n")
+			continue
+		}
+		if cs.Language == codegen.Docs {
+			fmt.Printf("Successfully generated API reference docs.\n")
+			continue
+		}
+		if cs.Language == codegen.YAML {
+			fmt.Printf("Successfully generated Pulumi YAML schema.\n")
+			continue
+		}
+		fmt.Printf("Successfully generated %s code.\n", cs.Language)
+	}
+	return nil
+}
+
+// printDiagnostics prints the fields of the CRDs that can't be represented exactly, followed by a summary. With strict,
+// an error is returned if there are any.
+func printDiagnostics(diagnostics []codegen.Diagnostic, strict bool) error {
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("Lossy %s\n", diagnostic)
+	}
+	crds := map[string]bool{}
+	for _, diagnostic := range diagnostics {
+		crds[diagnostic.CRD] = true
+	}
+	if strict {
+		return fmt.Errorf("%d fields of %d CRDs can't be represented exactly (--strict)", len(diagnostics), len(crds))
+	}
+	fmt.Printf("%d fields of %d CRDs can't be represented exactly; use --strict to fail instead.\n", len(diagnostics), len(crds))
+	return nil
+}
+
+// printChanges prints every changed file, and its diff if `diff` is true. Returns ErrChanges if there are any.
+func printChanges(changes []codegen.FileChange, diff bool) error {
+	if len(changes) == 0 {
+		fmt.Printf("Generated code is up to date.\n")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Printf("%-8s %s\n", change.Kind, change.Path)
+	}
+	if diff {
+		for _, change := range changes {
+			fmt.Printf("\n%s", change.Diff)
+		}
+	}
+	fmt.Printf("%d files would change.\n", len(changes))
+	return ErrChanges
+}
+
+// readCRDsFromCluster lists the CRDs selected by `opts` from a live cluster and returns them as YAML documents.
+func readCRDsFromCluster(ctx context.Context, opts cluster.Options) ([][]byte, error) {
+	config, err := cluster.RESTConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	crds, err := cluster.ListCRDs(ctx, config, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading CRDs from cluster %q: %w", config.Host, err)
+	}
+	if len(crds) == 0 {
+		return nil, fmt.Errorf("no CRDs found in cluster %q", config.Host)
+	}
+	return cluster.Marshal(crds)
+}
+
+// readers returns a fresh reader for each document.
+func readers(documents [][]byte) []io.ReadCloser {
+	rcs := make([]io.ReadCloser, 0, len(documents))
+	for _, document := range documents {
+		rcs = append(rcs, io.NopCloser(bytes.NewReader(document)))
+	}
+	return rcs
+}
+
+// packageOptions returns the options set by the persistent flags that select and shape the generated SDK, so that the
+// subcommands reading CRDs see the same SDK as the root command generates.
+func packageOptions(cmd *cobra.Command) []codegen.PackageOption {
+	f := cmd.Flags()
+	var filter codegen.Filter
+	filter.IncludeGroups, _ = f.GetStringSlice("include-group")
+	filter.ExcludeGroups, _ = f.GetStringSlice("exclude-group")
+	filter.IncludeKinds, _ = f.GetStringSlice("include-kind")
+	filter.ExcludeKinds, _ = f.GetStringSlice("exclude-kind")
+	filter.Versions, _ = f.GetStringSlice("versions")
+	versionPolicy, _ := f.GetString("version-policy")
+	filter.VersionPolicy = codegen.VersionPolicy(versionPolicy)
+	groupModules, _ := f.GetStringToString("group-module")
+	outputOnly, _ := f.GetStringSlice("output-only")
+	immutable, _ := f.GetStringSlice("immutable")
+	return []codegen.PackageOption{
+		codegen.WithFilter(filter),
+		codegen.WithGroupModules(groupModules),
+		codegen.WithOutputOnly(outputOnly),
+		codegen.WithImmutable(immutable),
+	}
+}
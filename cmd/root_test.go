@@ -0,0 +1,47 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/pulumi/crd2pulumi/pkg/codegen"
+)
+
+func TestPrintChanges(t *testing.T) {
+	if err := printChanges(nil, false); err != nil {
+		t.Errorf("expected no error without changes, got %v", err)
+	}
+	changes := []codegen.FileChange{{Kind: codegen.FileChanged, Path: "index.ts"}}
+	if err := printChanges(changes, false); !errors.Is(err, ErrChanges) {
+		t.Errorf("expected ErrChanges, got %v", err)
+	}
+}
+
+func TestErrorsAreNotPrinted(t *testing.T) {
+	var stderr bytes.Buffer
+	rootCmd := New()
+	rootCmd.SetArgs([]string{"generate", "-c", "missing.yaml"})
+	rootCmd.SetOut(&stderr)
+	rootCmd.SetErr(&stderr)
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatalf("expected an error for a missing config file")
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("expected errors to be left to main, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pulumi/crd2pulumi/pkg/codegen"
+	"github.com/spf13/cobra"
+)
+
+const validateLong = `Validates custom resource manifests against the schemas of their CRDs, without
+a cluster, the way the API server does when they are applied.
+
+Every document is checked against the schema of its CRD version: unknown
+fields, values of the wrong type, missing required fields, enum and pattern
+violations, duplicate items of sets and failing x-kubernetes-validations CEL
+rules are reported with the JSON path of the field. Transition rules, which
+compare a field with its previous value, are skipped like on create. A
+namespace set on a cluster-scoped kind, which the API server silently drops,
+is reported too.
+
+Documents of API groups that aren't defined by the CRDs, such as ConfigMaps,
+are skipped. The exit code is 1 if any custom resource is invalid, so it can
+be used in pre-commit hooks and CI.`
+
+func newValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:          "validate <crds.yaml> <manifest.yaml> [manifest.yaml ...]",
+		Short:        "Validate custom resource manifests against their CRDs",
+		Long:         validateLong,
+		Example:      `crd2pulumi validate cert-manager.crds.yaml certificates/*.yaml`,
+		SilenceUsage: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(2)(cmd, args); err != nil {
+				return errors.New("must specify a CRD YAML file and at least one manifest")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			crds, err := codegen.OpenSources(args[:1])
+			if err != nil {
+				return fmt.Errorf("error validating manifests: %w", err)
+			}
+			validator, err := codegen.NewValidator(crds)
+			if err != nil {
+				return fmt.Errorf("error validating manifests: %w", err)
+			}
+			manifests, err := codegen.OpenSources(args[1:])
+			if err != nil {
+				return fmt.Errorf("error validating manifests: %w", err)
+			}
+
+			var resources, errs int
+			for i, manifest := range manifests {
+				path := args[i+1]
+				result, err := validator.Validate(manifest)
+				if err != nil {
+					return fmt.Errorf("error validating %s: %w", path, err)
+				}
+				for _, skipped := range result.Skipped {
+					fmt.Printf("Skipped %s: %s\n", path, skipped)
+				}
+				for _, err := range result.Errors {
+					fmt.Printf("%s: %s\n", path, err)
+				}
+				resources += result.Resources
+				errs += len(result.Errors)
+			}
+
+			if errs > 0 {
+				return fmt.Errorf("found %d errors in %d custom resources", errs, resources)
+			}
+			fmt.Printf("Validated %d custom resources.\n", resources)
+			return nil
+		},
+	}
+}
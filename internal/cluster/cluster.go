@@ -0,0 +1,139 @@
+// Package cluster has functions for reading CustomResourceDefinitions from a live Kubernetes cluster.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/pulumi/crd2pulumi/internal/unstruct"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+// lastAppliedConfigAnnotation is set by `kubectl apply` and duplicates the whole CRD, so it is stripped.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// listPageSize is the number of CRDs requested from the API server at a time.
+const listPageSize = 100
+
+// Options selects the cluster to read from and the CRDs to read.
+type Options struct {
+	// Kubeconfig is the path to a kubeconfig file. If empty, the default loading rules are used ($KUBECONFIG, then
+	// ~/.kube/config).
+	Kubeconfig string
+	// Context is the kubeconfig context to use. If empty, the current context is used.
+	Context string
+	// LabelSelector restricts the listed CRDs to those matching the selector, e.g. "app=cert-manager".
+	LabelSelector string
+	// Groups restricts the listed CRDs to those whose API group matches one of the given glob patterns, e.g.
+	// "*.cert-manager.io". If empty, CRDs of every group are listed.
+	Groups []string
+}
+
+// RESTConfig returns the client configuration for the cluster selected by the kubeconfig and context in `opts`.
+func RESTConfig(opts Options) (*rest.Config, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = opts.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: opts.Context}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not load kubeconfig: %w", err)
+	}
+	return config, nil
+}
+
+// ListCRDs lists the apiextensions.k8s.io/v1 CustomResourceDefinitions served by the cluster at `config` that match
+// `opts`. Server-populated fields such as managedFields and status are stripped from the returned CRDs, which are
+// sorted by name.
+func ListCRDs(ctx context.Context, config *rest.Config, opts Options) ([]extensionv1.CustomResourceDefinition, error) {
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not create Kubernetes client: %w", err)
+	}
+
+	var crds []extensionv1.CustomResourceDefinition
+	listOptions := metav1.ListOptions{LabelSelector: opts.LabelSelector, Limit: listPageSize}
+	for {
+		list, err := client.ApiextensionsV1().CustomResourceDefinitions().List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("could not list CustomResourceDefinitions: %w", err)
+		}
+		for _, crd := range list.Items {
+			matches, err := matchesGroup(crd.Spec.Group, opts.Groups)
+			if err != nil {
+				return nil, err
+			}
+			if matches {
+				crds = append(crds, stripServerFields(crd))
+			}
+		}
+		if list.Continue == "" {
+			break
+		}
+		listOptions.Continue = list.Continue
+	}
+
+	sort.Slice(crds, func(i, j int) bool {
+		return crds[i].Name < crds[j].Name
+	})
+	return crds, nil
+}
+
+// Marshal returns a YAML document for each of the given CRDs, suitable for passing to codegen.Generate.
+func Marshal(crds []extensionv1.CustomResourceDefinition) ([][]byte, error) {
+	documents := make([][]byte, 0, len(crds))
+	for _, crd := range crds {
+		document, err := yaml.Marshal(crd)
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal CRD %q: %w", crd.Name, err)
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// matchesGroup returns true if `group` matches one of the glob `patterns`, or if there are no patterns.
+func matchesGroup(group string, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, pattern := range patterns {
+		matched, err := path.Match(pattern, group)
+		if err != nil {
+			return false, fmt.Errorf("invalid group pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// stripServerFields removes the fields populated by the API server, leaving the CRD as it would have been authored.
+// List items don't carry their TypeMeta, so it is set here as well.
+func stripServerFields(crd extensionv1.CustomResourceDefinition) extensionv1.CustomResourceDefinition {
+	crd.APIVersion = extensionv1.SchemeGroupVersion.String()
+	crd.Kind = unstruct.CRD
+	crd.ObjectMeta = metav1.ObjectMeta{
+		Name:        crd.Name,
+		Labels:      crd.Labels,
+		Annotations: crd.Annotations,
+	}
+	if _, ok := crd.Annotations[lastAppliedConfigAnnotation]; ok {
+		annotations := make(map[string]string, len(crd.Annotations)-1)
+		for k, v := range crd.Annotations {
+			if k != lastAppliedConfigAnnotation {
+				annotations[k] = v
+			}
+		}
+		crd.Annotations = annotations
+	}
+	crd.Status = extensionv1.CustomResourceDefinitionStatus{}
+	return crd
+}
@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pulumi/crd2pulumi/internal/unstruct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func testCRD(name, group string) extensionv1.CustomResourceDefinition {
+	return extensionv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			ResourceVersion: "12345",
+			UID:             "0c6a2a5e-7f0e-4b6e-9d43-6f2b0f8d1c11",
+			Annotations: map[string]string{
+				lastAppliedConfigAnnotation: "{}",
+				"owner":                     "platform",
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec: extensionv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: extensionv1.CustomResourceDefinitionNames{Kind: "Test", Plural: "tests"},
+			Scope: extensionv1.NamespaceScoped,
+		},
+		Status: extensionv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1"}},
+	}
+}
+
+// fakeAPIServer serves a paginated list of CRDs, recording the label selector of the last request.
+func fakeAPIServer(t *testing.T, pages [][]extensionv1.CustomResourceDefinition, selector *string) *rest.Config {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/apis/apiextensions.k8s.io/v1/customresourcedefinitions", r.URL.Path)
+		*selector = r.URL.Query().Get("labelSelector")
+
+		page := 0
+		if r.URL.Query().Get("continue") != "" {
+			page = 1
+		}
+		list := extensionv1.CustomResourceDefinitionList{Items: pages[page]}
+		if page < len(pages)-1 {
+			list.Continue = "next"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(list))
+	}))
+	t.Cleanup(server.Close)
+	return &rest.Config{Host: server.URL}
+}
+
+func TestListCRDs(t *testing.T) {
+	var selector string
+	config := fakeAPIServer(t, [][]extensionv1.CustomResourceDefinition{
+		{
+			testCRD("issuers.cert-manager.io", "cert-manager.io"),
+			testCRD("challenges.acme.cert-manager.io", "acme.cert-manager.io"),
+		},
+		{
+			testCRD("gateways.networking.istio.io", "networking.istio.io"),
+		},
+	}, &selector)
+
+	crds, err := ListCRDs(context.Background(), config, Options{
+		LabelSelector: "app=cert-manager",
+		Groups:        []string{"cert-manager.io", "*.cert-manager.io"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "app=cert-manager", selector)
+
+	require.Len(t, crds, 2)
+	assert.Equal(t, "challenges.acme.cert-manager.io", crds[0].Name)
+	assert.Equal(t, "issuers.cert-manager.io", crds[1].Name)
+	for _, crd := range crds {
+		assert.Equal(t, "apiextensions.k8s.io/v1", crd.APIVersion)
+		assert.Equal(t, unstruct.CRD, crd.Kind)
+		assert.Empty(t, crd.ResourceVersion)
+		assert.Empty(t, crd.UID)
+		assert.Empty(t, crd.ManagedFields)
+		assert.Empty(t, crd.Status.StoredVersions)
+		assert.Equal(t, map[string]string{"owner": "platform"}, crd.Annotations)
+	}
+}
+
+func TestListCRDsInvalidGroupPattern(t *testing.T) {
+	var selector string
+	config := fakeAPIServer(t, [][]extensionv1.CustomResourceDefinition{
+		{testCRD("issuers.cert-manager.io", "cert-manager.io")},
+	}, &selector)
+
+	_, err := ListCRDs(context.Background(), config, Options{Groups: []string{"["}})
+	assert.ErrorContains(t, err, `invalid group pattern "["`)
+}
+
+func TestMarshal(t *testing.T) {
+	documents, err := Marshal([]extensionv1.CustomResourceDefinition{
+		stripServerFields(testCRD("issuers.cert-manager.io", "cert-manager.io")),
+	})
+	require.NoError(t, err)
+
+	crds, err := unstruct.UnmarshalYamls(documents)
+	require.NoError(t, err)
+	require.Len(t, crds, 1)
+	assert.Equal(t, "cert-manager.io", crds[0].Spec.Group)
+}
@@ -0,0 +1,294 @@
+// Package config loads crd2pulumi project configuration files.
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/crd2pulumi/internal/cluster"
+	"github.com/pulumi/crd2pulumi/pkg/codegen"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultFileName is the name of the configuration file read when none is given.
+const DefaultFileName = "crd2pulumi.yaml"
+
+// DefaultVersion is the version of the generated packages when none is given.
+const DefaultVersion = "0.0.0-dev"
+
+//go:embed schema.json
+var schemaJSON string
+
+const schemaURL = "crd2pulumi.schema.json"
+
+var schema = jsonschema.MustCompileString(schemaURL, schemaJSON)
+
+// languages is the order in which the configured languages are generated.
+var languages = []string{codegen.DotNet, codegen.Go, codegen.NodeJS, codegen.Python, codegen.Java, codegen.Schema, codegen.Docs, codegen.YAML}
+
+// Config is the contents of a crd2pulumi project configuration file.
+type Config struct {
+	// Version is the version of the generated packages, unless overridden per language.
+	Version string `json:"version,omitempty"`
+	// Force overwrites existing output directories.
+	Force bool `json:"force,omitempty"`
+	// Prune deletes previously generated files that are no longer generated.
+	Prune bool `json:"prune,omitempty"`
+	// ValidationHelpers adds a validation module to the NodeJS, Python and Go packages.
+	ValidationHelpers bool `json:"validationHelpers,omitempty"`
+	// LookupFunctions adds functions that read existing resources by name and namespace to every language.
+	LookupFunctions bool `json:"lookupFunctions,omitempty"`
+	// Strict fails generation if any field of the CRDs can't be represented exactly.
+	Strict bool `json:"strict,omitempty"`
+	// Sources are the CRD YAML files and URLs to generate from.
+	Sources []string `json:"sources,omitempty"`
+	// Cluster reads the CRDs from a live cluster instead of Sources.
+	Cluster *Cluster `json:"cluster,omitempty"`
+	// Filters selects the CRDs and versions to generate.
+	Filters *Filters `json:"filters,omitempty"`
+	// OutputOnly are the top-level properties that can be read but not set, replacing the default of status.
+	OutputOnly []string `json:"outputOnly,omitempty"`
+	// Immutable are the property paths, besides those guarded by self == oldSelf rules, that replace the resource when
+	// changed. Paths prefixed with ! are updated in place even if detected.
+	Immutable []string `json:"immutable,omitempty"`
+	// GroupModules overrides the module each API group is generated into.
+	GroupModules map[string]string `json:"groupModules,omitempty"`
+	// Languages maps each language to generate to its settings.
+	Languages map[string]Language `json:"languages"`
+
+	// dir is the directory relative paths are resolved against.
+	dir string
+}
+
+// Cluster selects the cluster and CRDs to read, like the --from-cluster flags. The CRDs are also selected by the
+// groups of Filters.IncludeGroups.
+type Cluster struct {
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	Context    string `json:"context,omitempty"`
+	Selector   string `json:"selector,omitempty"`
+}
+
+// Filters selects the CRDs and versions to generate, like the --include-* and --exclude-* flags.
+type Filters struct {
+	IncludeGroups []string `json:"includeGroups,omitempty"`
+	ExcludeGroups []string `json:"excludeGroups,omitempty"`
+	IncludeKinds  []string `json:"includeKinds,omitempty"`
+	ExcludeKinds  []string `json:"excludeKinds,omitempty"`
+	Versions      []string `json:"versions,omitempty"`
+	VersionPolicy string   `json:"versionPolicy,omitempty"`
+}
+
+// Language holds the settings of a single generated language.
+type Language struct {
+	// Name is the name of the generated package.
+	Name string `json:"name,omitempty"`
+	// Namespace is the .NET namespace, NodeJS scope, Python package prefix or Java base package.
+	Namespace string `json:"namespace,omitempty"`
+	// OutputDir is the output directory, defaulting to <name>/<language>.
+	OutputDir string `json:"outputDir,omitempty"`
+	// Version overrides the version of the generated package.
+	Version string `json:"version,omitempty"`
+	// Format is the format of the generated Pulumi schema or API reference docs. Only valid for the schema and docs
+	// languages.
+	Format string `json:"format,omitempty"`
+}
+
+// Load reads and validates the configuration file at `path`. Relative paths in the file are resolved against the
+// directory containing it.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file: %w", err)
+	}
+	config, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	config.dir = filepath.Dir(path)
+	return config, nil
+}
+
+// Parse validates the YAML or JSON configuration in `data` against the configuration schema and returns it. Relative
+// paths are resolved against the working directory.
+func Parse(data []byte) (*Config, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var document any
+	if err := json.Unmarshal(jsonData, &document); err != nil {
+		return nil, err
+	}
+	if err := schema.Validate(document); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, validationErrors(validationErr)
+		}
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(jsonData, &config); err != nil {
+		return nil, err
+	}
+	if len(config.Sources) > 0 && config.Cluster != nil {
+		return nil, errors.New("sources and cluster cannot both be set")
+	}
+	if len(config.Sources) == 0 && config.Cluster == nil {
+		return nil, errors.New("one of sources or cluster must be set")
+	}
+	return &config, nil
+}
+
+// validationErrors flattens a schema validation error into one error per offending key.
+func validationErrors(err *jsonschema.ValidationError) error {
+	var messages []string
+	var flatten func(*jsonschema.ValidationError)
+	flatten = func(err *jsonschema.ValidationError) {
+		if len(err.Causes) == 0 {
+			messages = append(messages, fmt.Sprintf("%s: %s", keyPath(err.InstanceLocation), err.Message))
+		}
+		for _, cause := range err.Causes {
+			flatten(cause)
+		}
+	}
+	flatten(err)
+
+	sort.Strings(messages)
+	errs := make([]error, 0, len(messages))
+	for i, message := range messages {
+		if i == 0 || message != messages[i-1] {
+			errs = append(errs, errors.New(message))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// keyPath converts a JSON pointer such as /languages/python to a dotted key path such as languages.python.
+func keyPath(pointer string) string {
+	if pointer == "" {
+		return "(root)"
+	}
+	keys := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, key := range keys {
+		keys[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+// Settings returns the codegen settings of every configured language.
+func (c *Config) Settings() []*codegen.CodegenSettings {
+	version := c.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+
+	settings := make([]*codegen.CodegenSettings, 0, len(c.Languages))
+	for _, lang := range languages {
+		l, ok := c.Languages[lang]
+		if !ok {
+			continue
+		}
+		cs := &codegen.CodegenSettings{
+			Language:          lang,
+			OutputDir:         l.OutputDir,
+			PackageName:       l.Name,
+			PackageNamespace:  l.Namespace,
+			PackageVersion:    l.Version,
+			Overwrite:         c.Force,
+			Prune:             c.Prune,
+			ValidationHelpers: c.ValidationHelpers,
+			ShouldGenerate:    true,
+		}
+		switch lang {
+		case codegen.Schema:
+			cs.SchemaFormat = l.Format
+		case codegen.Docs:
+			cs.DocsFormat = l.Format
+		}
+		if cs.PackageName == "" {
+			cs.PackageName = codegen.DefaultName
+		}
+		if cs.PackageVersion == "" {
+			cs.PackageVersion = version
+		}
+		cs.OutputDir = c.resolve(cs.Path())
+		settings = append(settings, cs)
+	}
+	return settings
+}
+
+// SourcePaths returns the configured sources, with relative file paths resolved.
+func (c *Config) SourcePaths() []string {
+	paths := make([]string, 0, len(c.Sources))
+	for _, source := range c.Sources {
+		if strings.HasPrefix(source, "https://") {
+			paths = append(paths, source)
+		} else {
+			paths = append(paths, c.resolve(source))
+		}
+	}
+	return paths
+}
+
+// ClusterOptions returns the options used to read CRDs from the configured cluster.
+func (c *Config) ClusterOptions() cluster.Options {
+	if c.Cluster == nil {
+		return cluster.Options{}
+	}
+	opts := cluster.Options{
+		Context:       c.Cluster.Context,
+		LabelSelector: c.Cluster.Selector,
+		Groups:        c.Filter().IncludeGroups,
+	}
+	if c.Cluster.Kubeconfig != "" {
+		opts.Kubeconfig = c.resolve(c.Cluster.Kubeconfig)
+	}
+	return opts
+}
+
+// Filter returns the configured CRD filter.
+func (c *Config) Filter() codegen.Filter {
+	if c.Filters == nil {
+		return codegen.Filter{}
+	}
+	return codegen.Filter{
+		IncludeGroups: c.Filters.IncludeGroups,
+		ExcludeGroups: c.Filters.ExcludeGroups,
+		IncludeKinds:  c.Filters.IncludeKinds,
+		ExcludeKinds:  c.Filters.ExcludeKinds,
+		Versions:      c.Filters.Versions,
+		VersionPolicy: codegen.VersionPolicy(c.Filters.VersionPolicy),
+	}
+}
+
+// PackageOptions returns the options used to read the CRDs into a package.
+func (c *Config) PackageOptions() []codegen.PackageOption {
+	opts := []codegen.PackageOption{
+		codegen.WithFilter(c.Filter()),
+		codegen.WithGroupModules(c.GroupModules),
+		codegen.WithImmutable(c.Immutable),
+		codegen.WithLookupFunctions(c.LookupFunctions),
+		codegen.WithStrict(c.Strict),
+	}
+	if c.OutputOnly != nil {
+		opts = append(opts, codegen.WithOutputOnly(c.OutputOnly))
+	}
+	return opts
+}
+
+// resolve returns `path` relative to the configuration file's directory, unless it is absolute.
+func (c *Config) resolve(path string) string {
+	if filepath.IsAbs(path) || c.dir == "" {
+		return path
+	}
+	return filepath.Join(c.dir, path)
+}
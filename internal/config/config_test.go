@@ -0,0 +1,228 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pulumi/crd2pulumi/pkg/codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr []string
+	}{
+		{
+			name:    "Missing languages",
+			config:  "sources: [crds.yaml]",
+			wantErr: []string{"(root): missing properties: 'languages'"},
+		},
+		{
+			name: "Unknown language setting",
+			config: `
+sources: [crds.yaml]
+languages:
+  python:
+    outptDir: sdk/python`,
+			wantErr: []string{"languages.python: additionalProperties 'outptDir' not allowed"},
+		},
+		{
+			name: "Unknown language",
+			config: `
+sources: [crds.yaml]
+languages:
+  rust: {}`,
+			wantErr: []string{"languages: additionalProperties 'rust' not allowed"},
+		},
+		{
+			name: "Wrong types",
+			config: `
+sources: crds.yaml
+force: "yes"
+languages:
+  schema:
+    format: toml`,
+			wantErr: []string{
+				"force: expected boolean, but got string",
+				"languages.schema.format: value must be one of \"json\", \"yaml\"",
+				"sources: expected array, but got string",
+			},
+		},
+		{
+			name: "Schema named kubernetes",
+			config: `
+sources: [crds.yaml]
+languages:
+  schema:
+    name: kubernetes`,
+			wantErr: []string{"languages.schema.name: not failed"},
+		},
+		{
+			name: "Invalid group module",
+			config: `
+sources: [crds.yaml]
+groupModules:
+  networking.gke.io: gke-networking
+languages:
+  go: {}`,
+			wantErr: []string{"groupModules.networking.gke.io: does not match pattern"},
+		},
+		{
+			name: "Nested output-only field",
+			config: `
+sources: [crds.yaml]
+outputOnly: [spec.image]
+languages:
+  go: {}`,
+			wantErr: []string{"outputOnly.0: does not match pattern"},
+		},
+		{
+			name: "Invalid immutable field",
+			config: `
+sources: [crds.yaml]
+immutable: ["spec.volumes[0].name"]
+languages:
+  go: {}`,
+			wantErr: []string{"immutable.0: does not match pattern"},
+		},
+		{
+			name: "Sources and cluster",
+			config: `
+sources: [crds.yaml]
+cluster: {context: staging}
+languages:
+  go: {}`,
+			wantErr: []string{"sources and cluster cannot both be set"},
+		},
+		{
+			name:    "No sources",
+			config:  "languages: {go: {}}",
+			wantErr: []string{"one of sources or cluster must be set"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.config))
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`
+version: 1.2.3
+force: true
+prune: true
+sources:
+  - crds/certificates.yaml
+  - https://example.com/crds.yaml
+languages:
+  python:
+    name: certmanager
+    namespace: acme
+    version: 2.0.0
+  go:
+    outputDir: sdk/go
+  schema:
+    format: yaml
+  docs:
+    format: html
+`), 0o600))
+
+	config, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(dir, "crds/certificates.yaml"), "https://example.com/crds.yaml"}, config.SourcePaths())
+	assert.Equal(t, []*codegen.CodegenSettings{
+		{
+			Language:       codegen.Go,
+			OutputDir:      filepath.Join(dir, "sdk/go"),
+			PackageName:    codegen.DefaultName,
+			PackageVersion: "1.2.3",
+			Overwrite:      true,
+			Prune:          true,
+			ShouldGenerate: true,
+		},
+		{
+			Language:         codegen.Python,
+			OutputDir:        filepath.Join(dir, "certmanager/python"),
+			PackageName:      "certmanager",
+			PackageNamespace: "acme",
+			PackageVersion:   "2.0.0",
+			Overwrite:        true,
+			Prune:            true,
+			ShouldGenerate:   true,
+		},
+		{
+			Language:       codegen.Schema,
+			OutputDir:      filepath.Join(dir, "crds/schema"),
+			PackageName:    codegen.DefaultName,
+			PackageVersion: "1.2.3",
+			Overwrite:      true,
+			Prune:          true,
+			ShouldGenerate: true,
+			SchemaFormat:   codegen.SchemaFormatYAML,
+		},
+		{
+			Language:       codegen.Docs,
+			OutputDir:      filepath.Join(dir, "crds/docs"),
+			PackageName:    codegen.DefaultName,
+			PackageVersion: "1.2.3",
+			Overwrite:      true,
+			Prune:          true,
+			ShouldGenerate: true,
+			DocsFormat:     codegen.DocsFormatHTML,
+		},
+	}, config.Settings())
+}
+
+func TestClusterOptions(t *testing.T) {
+	config, err := Parse([]byte(`
+cluster:
+  kubeconfig: /etc/kube/config
+  context: staging
+  selector: app=cert-manager
+filters:
+  includeGroups: ["*.cert-manager.io"]
+languages:
+  nodejs: {}
+`))
+	require.NoError(t, err)
+
+	opts := config.ClusterOptions()
+	assert.Equal(t, "/etc/kube/config", opts.Kubeconfig)
+	assert.Equal(t, "staging", opts.Context)
+	assert.Equal(t, "app=cert-manager", opts.LabelSelector)
+	assert.Equal(t, []string{"*.cert-manager.io"}, opts.Groups)
+}
+
+func TestFilter(t *testing.T) {
+	config, err := Parse([]byte(`
+sources: [crds.yaml]
+filters:
+  includeGroups: ["*.cert-manager.io"]
+  excludeKinds: [Challenge]
+  versions: [v1]
+  versionPolicy: served
+languages:
+  nodejs: {}
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, codegen.Filter{
+		IncludeGroups: []string{"*.cert-manager.io"},
+		ExcludeKinds:  []string{"Challenge"},
+		Versions:      []string{"v1"},
+		VersionPolicy: codegen.VersionPolicyServed,
+	}, config.Filter())
+}
@@ -26,7 +26,21 @@ func GroupPrefix(group string) (string, error) {
 	if group == "" {
 		return "", fmt.Errorf("group cannot be empty")
 	}
-	return removeNonAlphanumeric(strings.Split(group, ".")[0]), nil
+	return GroupLabels(group, 1)
+}
+
+// GroupLabels returns the first `n` words in the dot-separated group string
+// joined together, with all non-alphanumeric characters removed. For example,
+// GroupLabels("networking.istio.io", 2) returns "networkingistio".
+func GroupLabels(group string, n int) (string, error) {
+	if group == "" {
+		return "", fmt.Errorf("group cannot be empty")
+	}
+	labels := strings.Split(group, ".")
+	if n < len(labels) {
+		labels = labels[:n]
+	}
+	return removeNonAlphanumeric(strings.Join(labels, "")), nil
 }
 
 // Capitalizes and returns the given version. For example,
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -23,6 +24,10 @@ import (
 
 func main() {
 	err := cmd.New().Execute()
+	if errors.Is(err, cmd.ErrChanges) {
+		// Like `terraform plan -detailed-exitcode`, changes exit with 2 so they can be told apart from errors.
+		os.Exit(2)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
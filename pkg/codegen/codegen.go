@@ -2,10 +2,13 @@ package codegen
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/pulumi/crd2pulumi/internal/files"
 )
@@ -20,6 +23,9 @@ var codeGenFuncs = map[string]GenerateFunc{
 	NodeJS: GenerateNodeJS,
 	Python: GeneratePython,
 	Java:   GenerateJava,
+	Schema: GenerateSchema,
+	Docs:   GenerateDocs,
+	YAML:   GenerateYAML,
 }
 
 // PulumiToolName is a symbol that identifies to Pulumi the name of this program.
@@ -27,50 +33,180 @@ const PulumiToolName = "crd2pulumi"
 
 // GenerateFromFiles performs the entire CRD codegen process.
 // The yamlPaths argument can contain both file paths and URLs.
-func GenerateFromFiles(cs *CodegenSettings, yamlPaths []string) error {
+func GenerateFromFiles(cs *CodegenSettings, yamlPaths []string, opts ...PackageOption) error {
+	return GenerateAllFromFiles([]*CodegenSettings{cs}, yamlPaths, opts...)
+}
+
+// GenerateAllFromFiles performs the entire CRD codegen process for every language in `settings`, reading each path
+// only once. The yamlPaths argument can contain both file paths and URLs.
+func GenerateAllFromFiles(settings []*CodegenSettings, yamlPaths []string, opts ...PackageOption) error {
+	yamlReaders, err := OpenSources(yamlPaths)
+	if err != nil {
+		return err
+	}
+	return GenerateAll(settings, yamlReaders, opts...)
+}
+
+// OpenSources opens each of the given file paths and URLs for reading.
+func OpenSources(yamlPaths []string) ([]io.ReadCloser, error) {
 	yamlReaders := make([]io.ReadCloser, 0, len(yamlPaths))
 	for _, yamlPath := range yamlPaths {
 		reader, err := files.ReadFromLocalOrRemote(yamlPath, map[string]string{"Accept": "application/x-yaml, text/yaml"})
 		if err != nil {
-			return fmt.Errorf("could not open YAML document at %s: %w", yamlPath, err)
+			for _, r := range yamlReaders {
+				r.Close()
+			}
+			return nil, fmt.Errorf("could not open YAML document at %s: %w", yamlPath, err)
 		}
 		yamlReaders = append(yamlReaders, reader)
 	}
-	return Generate(cs, yamlReaders)
+	return yamlReaders, nil
 }
 
 // Generate performs the entire CRD codegen process, reading YAML content from the given readers.
-func Generate(cs *CodegenSettings, yamls []io.ReadCloser) error {
-	generate, ok := codeGenFuncs[cs.Language]
-	if !ok {
-		return fmt.Errorf("unsupported language %q, must be one of %q", cs.Language, SupportedLanguages)
-	}
+func Generate(cs *CodegenSettings, yamls []io.ReadCloser, opts ...PackageOption) error {
+	return GenerateAll([]*CodegenSettings{cs}, yamls, opts...)
+}
 
-	if !cs.Overwrite {
-		if dirExists(cs.Path()) {
-			return fmt.Errorf("output already exists at %q, use --force to overwrite", cs.Path())
-		}
+// GenerateAll performs the entire CRD codegen process for every language in `settings`, reading YAML content from
+// the given readers. The CRDs are read and converted to a Pulumi schema only once, and then shared by all languages.
+func GenerateAll(settings []*CodegenSettings, yamls []io.ReadCloser, opts ...PackageOption) error {
+	if err := CheckSettings(settings); err != nil {
+		return err
 	}
 
 	// Do the actual reading of files from source, may take substantial time depending on the sources.
-	pg, err := ReadPackagesFromSource(cs.PackageVersion, yamls)
+	pg, err := ReadPackagesFromSource(settings[0].PackageVersion, yamls, opts...)
 	if err != nil {
 		return err
 	}
 
-	// Do actual codegen
-	output, err := generate(pg, cs)
+	return GeneratePackages(pg, settings)
+}
+
+// GeneratePackages generates the code for every language in `settings` from an already read PackageGenerator and
+// writes it to disk. Languages are generated concurrently, and all of their errors are returned.
+func GeneratePackages(pg *PackageGenerator, settings []*CodegenSettings) error {
+	if err := CheckSettings(settings); err != nil {
+		return err
+	}
+
+	// Build the shared package spec up front, so the languages don't race to build it.
+	if _, err := pg.PackageSpec(); err != nil {
+		return fmt.Errorf("could not generate Pulumi package spec: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(settings))
+	for i, cs := range settings {
+		wg.Go(func() {
+			errs[i] = generatePackage(pg.forVersion(cs.PackageVersion), cs)
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// CheckSettings returns an error if any of the languages can't be generated, so that it can be reported before any
+// CRDs are read.
+func CheckSettings(settings []*CodegenSettings) error {
+	return checkSettings(settings, true)
+}
+
+// checkSettings is CheckSettings, with the check for existing output directories only done if `checkExisting` is
+// true.
+func checkSettings(settings []*CodegenSettings, checkExisting bool) error {
+	if len(settings) == 0 {
+		return errors.New("no languages to generate")
+	}
+	outputDirs := map[string]*CodegenSettings{}
+	for _, cs := range settings {
+		if _, ok := codeGenFuncs[cs.Language]; !ok {
+			return fmt.Errorf("unsupported language %q, must be one of %q", cs.Language, SupportedLanguages)
+		}
+
+		path, err := filepath.Abs(cs.Path())
+		if err != nil {
+			return fmt.Errorf("could not resolve output directory %q: %w", cs.Path(), err)
+		}
+		if other, ok := outputDirs[path]; ok {
+			return fmt.Errorf("cannot generate both %q and %q packages to %q", other.Language, cs.Language, cs.Path())
+		}
+		// Languages are generated concurrently, and each output directory is swapped as a whole, so one can't be
+		// inside another.
+		for otherPath, other := range outputDirs {
+			if isWithin(otherPath, path) {
+				return fmt.Errorf("cannot generate the %q package to %q, inside the %q package at %q", cs.Language, cs.Path(), other.Language, other.Path())
+			}
+			if isWithin(path, otherPath) {
+				return fmt.Errorf("cannot generate the %q package to %q, inside the %q package at %q", other.Language, other.Path(), cs.Language, cs.Path())
+			}
+		}
+		outputDirs[path] = cs
+
+		if checkExisting && !cs.Overwrite {
+			if dirExists(cs.Path()) {
+				return fmt.Errorf("output already exists at %q, use --force to overwrite", cs.Path())
+			}
+		}
+	}
+	return nil
+}
+
+// isWithin returns whether `path` is inside the directory `dir`. Both paths must be absolute and clean.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// generatePackage generates the code for a single language and writes it to disk. If `cs.Prune` is set, the files
+// generated last time that are no longer generated are deleted.
+func generatePackage(pg *PackageGenerator, cs *CodegenSettings) error {
+	output, stale, err := generateFiles(pg, cs)
 	if err != nil {
-		return fmt.Errorf("failed to generate %q package %q: %w", cs.Language, cs.PackageName, err)
+		return err
 	}
 	// Write output to disk
-	err = writeFiles(output, cs.Path())
+	err = writeOutputDir(output, cs.Path(), stale)
 	if err != nil {
 		return fmt.Errorf("failed to write %q package %q to disk: %w", cs.Language, cs.PackageName, err)
 	}
 	return nil
 }
 
+// generateFiles generates the code for a single language, returning a mapping from file path to contents. The
+// mapping includes the manifest of the generated files. The files generated last time that are no longer generated
+// are returned as stale if `cs.Prune` is set, and otherwise stay listed in the manifest, so that they can be pruned
+// later.
+func generateFiles(pg *PackageGenerator, cs *CodegenSettings) (map[string]*bytes.Buffer, []string, error) {
+	generate := codeGenFuncs[cs.Language]
+
+	// Do actual codegen
+	output, err := generate(pg, cs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate %q package %q: %w", cs.Language, cs.PackageName, err)
+	}
+	previous, err := readManifest(cs.Path())
+	if err != nil {
+		return nil, nil, err
+	}
+	stale, err := staleFiles(previous, output, cs.Path())
+	if err != nil {
+		return nil, nil, err
+	}
+	kept := map[string]string{}
+	if !cs.Prune {
+		for _, path := range stale {
+			kept[path] = previous.Files[path]
+		}
+		stale = nil
+	}
+	if err := addManifest(output, kept); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate %q package %q: %w", cs.Language, cs.PackageName, err)
+	}
+	return output, stale, nil
+}
+
 // dirExists returns whether a given directory exists.
 func dirExists(filename string) bool {
 	info, err := os.Stat(filename)
@@ -89,12 +225,7 @@ func writeFiles(files map[string]*bytes.Buffer, outputDir string) error {
 		if err != nil {
 			return fmt.Errorf("could not create directory to %s: %w", outputFilePath, err)
 		}
-		file, err := os.Create(outputFilePath)
-		if err != nil {
-			return fmt.Errorf("could not create file %s: %w", outputFilePath, err)
-		}
-		defer file.Close()
-		if _, err := code.WriteTo(file); err != nil {
+		if err := os.WriteFile(outputFilePath, code.Bytes(), 0644); err != nil {
 			return fmt.Errorf("could not write to file %s: %w", outputFilePath, err)
 		}
 	}
@@ -0,0 +1,109 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func TestCheckSettings(t *testing.T) {
+	existingDir := t.TempDir()
+	tests := []struct {
+		name     string
+		settings []*CodegenSettings
+		wantErr  string
+	}{
+		{
+			name: "Distinct output dirs",
+			settings: []*CodegenSettings{
+				{Language: Go, OutputDir: filepath.Join(existingDir, "go")},
+				{Language: NodeJS, OutputDir: filepath.Join(existingDir, "nodejs")},
+			},
+		},
+		{
+			name: "Unsupported language",
+			settings: []*CodegenSettings{
+				{Language: "rust", OutputDir: filepath.Join(existingDir, "rust")},
+			},
+			wantErr: `unsupported language "rust"`,
+		},
+		{
+			name: "Shared output dir",
+			settings: []*CodegenSettings{
+				{Language: Go, OutputDir: filepath.Join(existingDir, "sdk")},
+				{Language: Python, OutputDir: filepath.Join(existingDir, "sdk") + "/"},
+			},
+			wantErr: `cannot generate both "go" and "python" packages`,
+		},
+		{
+			name: "Nested output dir",
+			settings: []*CodegenSettings{
+				{Language: Go, OutputDir: filepath.Join(existingDir, "sdk", "go")},
+				{Language: NodeJS, OutputDir: filepath.Join(existingDir, "sdk")},
+			},
+			wantErr: `cannot generate the "go" package to "` + filepath.Join(existingDir, "sdk", "go") + `", inside the "nodejs" package`,
+		},
+		{
+			name: "Sibling output dirs with a common prefix",
+			settings: []*CodegenSettings{
+				{Language: Go, OutputDir: filepath.Join(existingDir, "sdk")},
+				{Language: NodeJS, OutputDir: filepath.Join(existingDir, "sdk-nodejs")},
+			},
+		},
+		{
+			name: "Existing output dir",
+			settings: []*CodegenSettings{
+				{Language: Go, OutputDir: existingDir},
+			},
+			wantErr: "use --force to overwrite",
+		},
+		{
+			name: "Existing output dir with overwrite",
+			settings: []*CodegenSettings{
+				{Language: Go, OutputDir: existingDir, Overwrite: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSettings(tt.settings)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckSettings() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckSettings() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForVersion(t *testing.T) {
+	pg := &PackageGenerator{Version: "1.0.0", packageSpec: &pschema.PackageSpec{Name: pulumiKubernetesNameShim}}
+	copied := pg.forVersion("2.0.0")
+	if copied.Version != "2.0.0" || pg.Version != "1.0.0" {
+		t.Errorf("expected versions 2.0.0 and 1.0.0, got %s and %s", copied.Version, pg.Version)
+	}
+	if copied.packageSpec != pg.packageSpec {
+		t.Errorf("expected the package spec to be shared")
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// APIChangeLevel is how a change to the generated SDKs affects the programs using them.
+type APIChangeLevel string
+
+const (
+	// APIChangeBreaking changes can break programs, e.g. removed resources or properties.
+	APIChangeBreaking APIChangeLevel = "breaking"
+	// APIChangeFeature changes add to the SDKs without breaking programs, e.g. new resources or optional properties.
+	APIChangeFeature APIChangeLevel = "feature"
+	// APIChangePatch changes don't affect the API of the SDKs, e.g. new descriptions.
+	APIChangePatch APIChangeLevel = "patch"
+)
+
+// APIChange is a change to the resources, types or modules of the generated SDKs.
+type APIChange struct {
+	Level APIChangeLevel `json:"level"`
+	// Token is the token of the resource or type that changed. It's empty for changes to modules.
+	Token string `json:"token,omitempty"`
+	// Property is the name of the property that changed, if any.
+	Property string `json:"property,omitempty"`
+	Message  string `json:"message"`
+}
+
+// APIReport lists the changes between two versions of the generated SDKs, and the semver bump they call for.
+type APIReport struct {
+	Changes []APIChange `json:"changes"`
+	// Bump is "major" if any change is breaking, "minor" if any change is a feature, "patch" if there are only patch
+	// changes and "none" if there are no changes.
+	Bump string `json:"bump"`
+	// Version is the version to generate the new SDKs with, if the version of the old ones was given.
+	Version string `json:"version,omitempty"`
+}
+
+// ComparePackages returns the changes to the generated SDKs between the packages generated from the `old` and `new`
+// CRDs: removed, added and deprecated resources, types and properties, properties becoming required, type changes,
+// enum values and modules. If `oldVersion` is the semver of the SDKs generated from `old`, the report suggests the
+// version of the new ones.
+func ComparePackages(old, new *PackageGenerator, oldVersion string) (*APIReport, error) {
+	oldSpec, err := old.PackageSpec()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Pulumi package spec of the old CRDs: %w", err)
+	}
+	newSpec, err := new.PackageSpec()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Pulumi package spec of the new CRDs: %w", err)
+	}
+	oldModules, err := old.languageModules("")
+	if err != nil {
+		return nil, err
+	}
+	newModules, err := new.languageModules("")
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []APIChange{}
+	for _, groupVersion := range slices.Sorted(maps.Keys(oldModules)) {
+		if module, ok := newModules[groupVersion]; ok && module != oldModules[groupVersion] {
+			changes = append(changes, APIChange{
+				Level:   APIChangeBreaking,
+				Message: fmt.Sprintf("module of %s renamed from %s to %s", groupVersion, oldModules[groupVersion], module),
+			})
+		}
+	}
+	changes = append(changes, compareResources(oldSpec.Resources, newSpec.Resources)...)
+	changes = append(changes, compareTypes(oldSpec.Types, newSpec.Types)...)
+
+	report := &APIReport{Changes: changes, Bump: semverBump(changes)}
+	if oldVersion != "" {
+		report.Version, err = nextVersion(oldVersion, report.Bump)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// compareResources returns the changes to `old` resources, sorted by token.
+func compareResources(old, new map[string]pschema.ResourceSpec) []APIChange {
+	changes := []APIChange{}
+	for _, token := range sortedUnion(old, new) {
+		oldResource, inOld := old[token]
+		newResource, inNew := new[token]
+		switch {
+		case !inNew:
+			changes = append(changes, APIChange{Level: APIChangeBreaking, Token: token, Message: "resource removed"})
+			continue
+		case !inOld:
+			changes = append(changes, APIChange{Level: APIChangeFeature, Token: token, Message: "resource added"})
+			continue
+		}
+		changes = append(changes, compareDocs(token, "resource", oldResource.ObjectTypeSpec, oldResource.DeprecationMessage,
+			newResource.ObjectTypeSpec, newResource.DeprecationMessage)...)
+
+		changes = append(changes, compareProperties(token, oldResource.Properties, newResource.Properties, nil, nil)...)
+		changes = append(changes, compareInputs(token, oldResource, newResource)...)
+	}
+	return changes
+}
+
+// compareInputs returns the changes to the inputs of a resource. Inputs are also outputs, so removed, added and
+// retyped properties are only reported here if they aren't outputs.
+func compareInputs(token string, old, new pschema.ResourceSpec) []APIChange {
+	changes := []APIChange{}
+	add := func(level APIChangeLevel, property, message string) {
+		changes = append(changes, APIChange{Level: level, Token: token, Property: property, Message: message})
+	}
+	for _, name := range sortedUnion(old.InputProperties, new.InputProperties) {
+		oldProperty, wasInput := old.InputProperties[name]
+		newProperty, isInput := new.InputProperties[name]
+		_, wasOutput := old.Properties[name]
+		_, isOutput := new.Properties[name]
+		wasRequired, isRequired := slices.Contains(old.RequiredInputs, name), slices.Contains(new.RequiredInputs, name)
+		switch {
+		case !isInput && isOutput:
+			add(APIChangeBreaking, name, "property is no longer an input")
+		case !isInput && !wasOutput:
+			add(APIChangeBreaking, name, "input removed")
+		case !wasInput && isRequired:
+			add(APIChangeBreaking, name, "required input added")
+		case !wasInput && wasOutput:
+			add(APIChangeFeature, name, "property became an input")
+		case !wasInput && !isOutput:
+			add(APIChangeFeature, name, "input added")
+		case wasInput && isInput:
+			if !wasOutput && !isOutput && !reflect.DeepEqual(oldProperty.TypeSpec, newProperty.TypeSpec) {
+				add(APIChangeBreaking, name, fmt.Sprintf("type changed from %s to %s", typeName(oldProperty.TypeSpec), typeName(newProperty.TypeSpec)))
+			}
+			if !wasRequired && isRequired {
+				add(APIChangeBreaking, name, "input became required")
+			}
+			if wasRequired && !isRequired {
+				add(APIChangeFeature, name, "input became optional")
+			}
+		}
+	}
+	return changes
+}
+
+// compareTypes returns the changes to `old` object and enum types, sorted by token.
+func compareTypes(old, new map[string]pschema.ComplexTypeSpec) []APIChange {
+	changes := []APIChange{}
+	for _, token := range sortedUnion(old, new) {
+		oldType, inOld := old[token]
+		newType, inNew := new[token]
+		switch {
+		case !inNew:
+			changes = append(changes, APIChange{Level: APIChangeBreaking, Token: token, Message: "type removed"})
+			continue
+		case !inOld:
+			changes = append(changes, APIChange{Level: APIChangeFeature, Token: token, Message: "type added"})
+			continue
+		case (len(oldType.Enum) > 0) != (len(newType.Enum) > 0) || oldType.Type != newType.Type:
+			changes = append(changes, APIChange{
+				Level: APIChangeBreaking, Token: token,
+				Message: fmt.Sprintf("type changed from %s to %s", complexTypeName(oldType), complexTypeName(newType)),
+			})
+			continue
+		}
+		changes = append(changes, compareDocs(token, "type", oldType.ObjectTypeSpec, "", newType.ObjectTypeSpec, "")...)
+		if len(oldType.Enum) > 0 {
+			changes = append(changes, compareEnumValues(token, oldType.Enum, newType.Enum)...)
+			continue
+		}
+		changes = append(changes, compareProperties(token, oldType.Properties, newType.Properties, oldType.Required, newType.Required)...)
+	}
+	return changes
+}
+
+// compareProperties returns the changes to the `old` properties of `token`. Types are used as both inputs and
+// outputs, so properties becoming either required or optional are breaking.
+func compareProperties(token string, old, new map[string]pschema.PropertySpec, oldRequired, newRequired []string) []APIChange {
+	changes := []APIChange{}
+	add := func(level APIChangeLevel, property, format string, args ...any) {
+		changes = append(changes, APIChange{Level: level, Token: token, Property: property, Message: fmt.Sprintf(format, args...)})
+	}
+	for _, name := range sortedUnion(old, new) {
+		oldProperty, inOld := old[name]
+		newProperty, inNew := new[name]
+		wasRequired, isRequired := slices.Contains(oldRequired, name), slices.Contains(newRequired, name)
+		switch {
+		case !inNew:
+			add(APIChangeBreaking, name, "property removed")
+			continue
+		case !inOld && isRequired:
+			add(APIChangeBreaking, name, "required property added")
+			continue
+		case !inOld:
+			add(APIChangeFeature, name, "property added")
+			continue
+		}
+
+		if !reflect.DeepEqual(oldProperty.TypeSpec, newProperty.TypeSpec) {
+			add(APIChangeBreaking, name, "type changed from %s to %s", typeName(oldProperty.TypeSpec), typeName(newProperty.TypeSpec))
+		}
+		switch {
+		case !wasRequired && isRequired:
+			add(APIChangeBreaking, name, "property became required")
+		case wasRequired && !isRequired:
+			add(APIChangeBreaking, name, "property became optional")
+		}
+		if oldProperty.DeprecationMessage == "" && newProperty.DeprecationMessage != "" {
+			add(APIChangeFeature, name, "property deprecated: %s", newProperty.DeprecationMessage)
+		}
+		if oldProperty.Description != newProperty.Description || !reflect.DeepEqual(oldProperty.Default, newProperty.Default) {
+			add(APIChangePatch, name, "description or default changed")
+		}
+	}
+	return changes
+}
+
+// compareDocs returns the changes to the description and deprecation of a resource or type.
+func compareDocs(token, kind string, old pschema.ObjectTypeSpec, oldDeprecation string, new pschema.ObjectTypeSpec, newDeprecation string) []APIChange {
+	changes := []APIChange{}
+	if oldDeprecation == "" && newDeprecation != "" {
+		changes = append(changes, APIChange{Level: APIChangeFeature, Token: token, Message: fmt.Sprintf("%s deprecated: %s", kind, newDeprecation)})
+	}
+	if old.Description != new.Description {
+		changes = append(changes, APIChange{Level: APIChangePatch, Token: token, Message: "description changed"})
+	}
+	return changes
+}
+
+// compareEnumValues returns the changes to the values of an enum type.
+func compareEnumValues(token string, old, new []pschema.EnumValueSpec) []APIChange {
+	values := func(enum []pschema.EnumValueSpec) []string {
+		names := make([]string, 0, len(enum))
+		for _, value := range enum {
+			names = append(names, fmt.Sprintf("%#v", value.Value))
+		}
+		return names
+	}
+	oldValues, newValues := values(old), values(new)
+
+	changes := []APIChange{}
+	for _, value := range oldValues {
+		if !slices.Contains(newValues, value) {
+			changes = append(changes, APIChange{Level: APIChangeBreaking, Token: token, Message: fmt.Sprintf("enum value %s removed", value)})
+		}
+	}
+	for _, value := range newValues {
+		if !slices.Contains(oldValues, value) {
+			changes = append(changes, APIChange{Level: APIChangeFeature, Token: token, Message: fmt.Sprintf("enum value %s added", value)})
+		}
+	}
+	return changes
+}
+
+// typeName describes the type of a property, e.g. "list of string".
+func typeName(typeSpec pschema.TypeSpec) string {
+	ref := docsTypeRefOf(typeSpec, func(string) string { return "" })
+	return ref.Prefix + ref.Name
+}
+
+// complexTypeName describes an object or enum type.
+func complexTypeName(typ pschema.ComplexTypeSpec) string {
+	if len(typ.Enum) > 0 {
+		return typ.Type + " enum"
+	}
+	return typ.Type
+}
+
+// sortedUnion returns the keys of `a` and `b`, sorted.
+func sortedUnion[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// semverBump returns the semver bump that `changes` call for.
+func semverBump(changes []APIChange) string {
+	switch {
+	case slices.ContainsFunc(changes, func(c APIChange) bool { return c.Level == APIChangeBreaking }):
+		return "major"
+	case slices.ContainsFunc(changes, func(c APIChange) bool { return c.Level == APIChangeFeature }):
+		return "minor"
+	case len(changes) > 0:
+		return "patch"
+	}
+	return "none"
+}
+
+// nextVersion returns `version` bumped by `bump`. Pre-release and build suffixes are dropped. Like semver, a major bump
+// of a 0.x version only bumps the minor version, as anything may change before 1.0.0.
+func nextVersion(version, bump string) (string, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	core, _, _ = strings.Cut(core, "+")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid version %q, must be a semver such as 1.2.3", version)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid version %q, must be a semver such as 1.2.3", version)
+		}
+		numbers[i] = n
+	}
+
+	switch bump {
+	case "major":
+		if numbers[0] == 0 {
+			numbers = []int{0, numbers[1] + 1, 0}
+		} else {
+			numbers = []int{numbers[0] + 1, 0, 0}
+		}
+	case "minor":
+		numbers = []int{numbers[0], numbers[1] + 1, 0}
+	case "patch":
+		numbers[2]++
+	}
+	return fmt.Sprintf("%d.%d.%d", numbers[0], numbers[1], numbers[2]), nil
+}
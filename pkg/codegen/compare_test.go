@@ -0,0 +1,98 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"reflect"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func TestComparePackages(t *testing.T) {
+	old := newCronTabTestPackageGenerator()
+	old.GroupVersions = []string{"stable.example.com/v1"}
+
+	new := newCronTabTestPackageGenerator()
+	new.GroupVersions = []string{"stable.example.com/v1"}
+	new.groupModules = map[string]string{"stable.example.com": "cron"}
+	spec := new.packageSpec
+	delete(spec.Resources, "kubernetes:stable.example.com/v1:Schedule")
+	spec.Resources["kubernetes:stable.example.com/v1:Job"] = pschema.ResourceSpec{}
+	cronTab := spec.Resources["kubernetes:stable.example.com/v1:CronTab"]
+	cronTab.Description = "A CronTab runs a job on a schedule."
+	cronTab.RequiredInputs = []string{"metadata", "spec"}
+	spec.Resources["kubernetes:stable.example.com/v1:CronTab"] = cronTab
+	cronTabSpec := spec.Types["kubernetes:stable.example.com/v1:CronTabSpec"]
+	cronTabSpec.Properties["replicas"] = pschema.PropertySpec{TypeSpec: pschema.TypeSpec{Type: String}, Default: 1}
+	cronTabSpec.Properties["image"] = pschema.PropertySpec{TypeSpec: pschema.TypeSpec{Type: String}}
+	policy := spec.Types["kubernetes:stable.example.com/v1:CronTabSpecPolicy"]
+	policy.Enum = []pschema.EnumValueSpec{{Name: "Forbid", Value: "Forbid"}, {Name: "Replace", Value: "Replace"}}
+	spec.Types["kubernetes:stable.example.com/v1:CronTabSpecPolicy"] = policy
+
+	report, err := ComparePackages(old, new, "1.4.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &APIReport{
+		Changes: []APIChange{
+			{Level: APIChangeBreaking, Message: "module of stable.example.com/v1 renamed from stable/v1 to cron/v1"},
+			{Level: APIChangePatch, Token: "kubernetes:stable.example.com/v1:CronTab", Message: "description changed"},
+			{Level: APIChangeBreaking, Token: "kubernetes:stable.example.com/v1:CronTab", Property: "metadata", Message: "input became required"},
+			{Level: APIChangeFeature, Token: "kubernetes:stable.example.com/v1:Job", Message: "resource added"},
+			{Level: APIChangeBreaking, Token: "kubernetes:stable.example.com/v1:Schedule", Message: "resource removed"},
+			{Level: APIChangeFeature, Token: "kubernetes:stable.example.com/v1:CronTabSpec", Property: "image", Message: "property added"},
+			{Level: APIChangeBreaking, Token: "kubernetes:stable.example.com/v1:CronTabSpec", Property: "replicas", Message: "type changed from integer to string"},
+			{Level: APIChangeBreaking, Token: "kubernetes:stable.example.com/v1:CronTabSpecPolicy", Message: `enum value "Allow" removed`},
+			{Level: APIChangeFeature, Token: "kubernetes:stable.example.com/v1:CronTabSpecPolicy", Message: `enum value "Replace" added`},
+		},
+		Bump:    "major",
+		Version: "2.0.0",
+	}
+	if !reflect.DeepEqual(want, report) {
+		t.Errorf("expected report:\n%+v\ngot:\n%+v", want, report)
+	}
+}
+
+func TestComparePackagesUnchanged(t *testing.T) {
+	report, err := ComparePackages(newCronTabTestPackageGenerator(), newCronTabTestPackageGenerator(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Changes) != 0 || report.Bump != "none" || report.Version != "" {
+		t.Errorf("expected no changes, got %+v", report)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		version, bump, want string
+	}{
+		{"1.4.2", "major", "2.0.0"},
+		{"1.4.2", "minor", "1.5.0"},
+		{"1.4.2", "patch", "1.4.3"},
+		{"1.4.2", "none", "1.4.2"},
+		{"v0.3.0-alpha.1+build", "minor", "0.4.0"},
+		{"0.3.2", "major", "0.4.0"},
+	}
+	for _, tt := range tests {
+		if got, err := nextVersion(tt.version, tt.bump); err != nil || got != tt.want {
+			t.Errorf("nextVersion(%q, %q): expected %q, got %q (%v)", tt.version, tt.bump, tt.want, got, err)
+		}
+	}
+	if _, err := nextVersion("1.4", "major"); err == nil {
+		t.Errorf("expected an invalid version error")
+	}
+}
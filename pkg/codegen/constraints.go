@@ -0,0 +1,214 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// addConstraints appends the validation constraints of the properties of the
+// resources generated from `crd`, such as minimum, pattern and maxItems, to
+// their descriptions, along with the CEL rules of their x-kubernetes-validations
+// and how server-side apply merges them. The rules and map types of objects are
+// added to the descriptions of their types instead.
+// crdToOpenAPI strips value validations before the package spec is generated,
+// so they are read from the CRD's schemas instead.
+func addConstraints(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) {
+	for _, v := range crd.Spec.Versions {
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		token := getToken(crd.Spec.Group, v.Name, crd.Spec.Names.Kind)
+		scope := getToken(crd.Spec.Group, v.Name, "")
+		if resource, ok := pkgSpec.Resources[token]; ok {
+			resource.Description = appendDocs(resource.Description, describeRules(v.Schema.OpenAPIV3Schema.XValidations))
+			pkgSpec.Resources[token] = resource
+		}
+		visited := map[string]bool{}
+		for _, t := range []string{token, token + "Patch"} {
+			resource, ok := pkgSpec.Resources[t]
+			if !ok {
+				continue
+			}
+			addPropertyConstraints(pkgSpec, scope, visited, resource.InputProperties, *v.Schema.OpenAPIV3Schema)
+			addPropertyConstraints(pkgSpec, scope, visited, resource.Properties, *v.Schema.OpenAPIV3Schema)
+		}
+	}
+}
+
+// addPropertyConstraints appends the constraints in the properties of `schema`
+// to the descriptions of the matching `properties`, and recurses into the
+// object types they refer to. Only types whose token starts with `scope` are
+// followed, and each only once.
+func addPropertyConstraints(
+	pkgSpec *pschema.PackageSpec, scope string, visited map[string]bool, properties map[string]pschema.PropertySpec,
+	schema extensionv1.JSONSchemaProps,
+) {
+	for name, property := range properties {
+		propertySchema, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		docs := []string{describeConstraints(propertySchema)}
+		if propertySchema.Type == Array {
+			docs = append(docs, describeListType(deref(propertySchema.XListType), propertySchema.XListMapKeys))
+		}
+		if !strings.HasPrefix(property.TypeSpec.Ref, typeRefPrefix+scope) {
+			docs = append(docs, describeRules(propertySchema.XValidations), describeMapType(deref(propertySchema.XMapType)))
+		}
+		property.Description = appendDocs(property.Description, docs...)
+		properties[name] = property
+		addTypeConstraints(pkgSpec, scope, visited, property.TypeSpec, propertySchema)
+	}
+}
+
+// addTypeConstraints adds the constraints of the object type `typeSpec`
+// refers to, directly or as the items or values of `schema`.
+func addTypeConstraints(
+	pkgSpec *pschema.PackageSpec, scope string, visited map[string]bool, typeSpec pschema.TypeSpec, schema extensionv1.JSONSchemaProps,
+) {
+	switch {
+	case typeSpec.Items != nil:
+		if schema.Items != nil && schema.Items.Schema != nil {
+			addTypeConstraints(pkgSpec, scope, visited, *typeSpec.Items, *schema.Items.Schema)
+		}
+	case typeSpec.AdditionalProperties != nil:
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			addTypeConstraints(pkgSpec, scope, visited, *typeSpec.AdditionalProperties, *schema.AdditionalProperties.Schema)
+		}
+	case strings.HasPrefix(typeSpec.Ref, typeRefPrefix+scope):
+		token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+		if typ, ok := pkgSpec.Types[token]; ok && !visited[token] {
+			visited[token] = true
+			typ.Description = appendDocs(typ.Description, describeRules(schema.XValidations), describeMapType(deref(schema.XMapType)))
+			pkgSpec.Types[token] = typ
+			if len(typ.Enum) == 0 {
+				addPropertyConstraints(pkgSpec, scope, visited, typ.Properties, schema)
+			}
+		}
+	}
+}
+
+// describeConstraints returns a sentence listing the validation constraints of
+// `schema`, and those of its items or values unless they are objects, which
+// have their own types. Returns "" if there are none.
+func describeConstraints(schema extensionv1.JSONSchemaProps) string {
+	constraints := schemaConstraints(schema, "")
+	if schema.Items != nil && schema.Items.Schema != nil && len(schema.Items.Schema.Properties) == 0 {
+		constraints = append(constraints, schemaConstraints(*schema.Items.Schema, "item ")...)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil &&
+		len(schema.AdditionalProperties.Schema.Properties) == 0 {
+		constraints = append(constraints, schemaConstraints(*schema.AdditionalProperties.Schema, "value ")...)
+	}
+	if len(constraints) == 0 {
+		return ""
+	}
+	return "Constraints: " + strings.Join(constraints, ", ") + "."
+}
+
+// schemaConstraints returns the validation constraints of `schema` itself, e.g. "minimum 1" or "pattern `^[a-z]+$`",
+// each starting with `prefix`.
+func schemaConstraints(schema extensionv1.JSONSchemaProps, prefix string) []string {
+	var constraints []string
+	add := func(format string, args ...any) {
+		constraints = append(constraints, prefix+fmt.Sprintf(format, args...))
+	}
+	if schema.Minimum != nil {
+		if schema.ExclusiveMinimum {
+			add("exclusive minimum %s", formatNumber(*schema.Minimum))
+		} else {
+			add("minimum %s", formatNumber(*schema.Minimum))
+		}
+	}
+	if schema.Maximum != nil {
+		if schema.ExclusiveMaximum {
+			add("exclusive maximum %s", formatNumber(*schema.Maximum))
+		} else {
+			add("maximum %s", formatNumber(*schema.Maximum))
+		}
+	}
+	if schema.MultipleOf != nil {
+		add("multiple of %s", formatNumber(*schema.MultipleOf))
+	}
+	if schema.MinLength != nil {
+		add("min length %d", *schema.MinLength)
+	}
+	if schema.MaxLength != nil {
+		add("max length %d", *schema.MaxLength)
+	}
+	if schema.Pattern != "" {
+		add("pattern `%s`", schema.Pattern)
+	}
+	if schema.Format != "" {
+		add("format %s", schema.Format)
+	}
+	if schema.MinItems != nil {
+		add("min items %d", *schema.MinItems)
+	}
+	if schema.MaxItems != nil {
+		add("max items %d", *schema.MaxItems)
+	}
+	if schema.UniqueItems {
+		add("unique items")
+	}
+	if schema.MinProperties != nil {
+		add("min properties %d", *schema.MinProperties)
+	}
+	if schema.MaxProperties != nil {
+		add("max properties %d", *schema.MaxProperties)
+	}
+	return constraints
+}
+
+// describeRules returns a Markdown list of the CEL rules of
+// x-kubernetes-validations and their messages, or "" if there are none.
+func describeRules(rules extensionv1.ValidationRules) string {
+	if len(rules) == 0 {
+		return ""
+	}
+	lines := []string{"Validation rules:"}
+	for _, rule := range rules {
+		line := "- `" + rule.Rule + "`"
+		switch {
+		case rule.Message != "":
+			line += ": " + rule.Message
+		case rule.MessageExpression != "":
+			line += ": `" + rule.MessageExpression + "`"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// appendDocs appends the non-empty `docs` to `description` as paragraphs.
+func appendDocs(description string, docs ...string) string {
+	for _, doc := range docs {
+		if doc != "" {
+			description = strings.TrimSpace(description + "\n\n" + doc)
+		}
+	}
+	return description
+}
+
+// formatNumber formats a JSON number without a trailing ".0" or exponent.
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
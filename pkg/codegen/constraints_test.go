@@ -0,0 +1,126 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestDescribeConstraints(t *testing.T) {
+	one, ten, half := 1.0, 10.0, 0.5
+	three, five := int64(3), int64(5)
+	tests := []struct {
+		name   string
+		schema extensionv1.JSONSchemaProps
+		want   string
+	}{
+		{
+			name:   "none",
+			schema: extensionv1.JSONSchemaProps{Type: String},
+			want:   "",
+		},
+		{
+			name:   "numbers",
+			schema: extensionv1.JSONSchemaProps{Type: Number, Minimum: &one, Maximum: &ten, ExclusiveMaximum: true, MultipleOf: &half},
+			want:   "Constraints: minimum 1, exclusive maximum 10, multiple of 0.5.",
+		},
+		{
+			name:   "strings",
+			schema: extensionv1.JSONSchemaProps{Type: String, MinLength: &three, MaxLength: &five, Pattern: "^[a-z]+$", Format: "hostname"},
+			want:   "Constraints: min length 3, max length 5, pattern `^[a-z]+$`, format hostname.",
+		},
+		{
+			name: "array items",
+			schema: extensionv1.JSONSchemaProps{Type: Array, MaxItems: &five, UniqueItems: true, Items: &extensionv1.JSONSchemaPropsOrArray{
+				Schema: &extensionv1.JSONSchemaProps{Type: String, MaxLength: &three},
+			}},
+			want: "Constraints: max items 5, unique items, item max length 3.",
+		},
+		{
+			name: "map values",
+			schema: extensionv1.JSONSchemaProps{Type: Object, MinProperties: &three, AdditionalProperties: &extensionv1.JSONSchemaPropsOrBool{
+				Schema: &extensionv1.JSONSchemaProps{Type: Integer, Minimum: &one},
+			}},
+			want: "Constraints: min properties 3, value minimum 1.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeConstraints(tt.schema); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAddConstraints(t *testing.T) {
+	one := 1.0
+	crd := filterTestCRD("stable.example.com", "CronTab")
+	crd.Spec.Versions = []extensionv1.CustomResourceDefinitionVersion{{
+		Name: "v1",
+		Schema: &extensionv1.CustomResourceValidation{OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+			Type: Object,
+			Properties: map[string]extensionv1.JSONSchemaProps{
+				"spec": {
+					Type: Object,
+					XValidations: extensionv1.ValidationRules{
+						{Rule: "self.replicas <= self.maxReplicas", Message: "replicas must not exceed maxReplicas"},
+					},
+					Properties: map[string]extensionv1.JSONSchemaProps{
+						"replicas": {Type: Integer, Minimum: &one},
+						"image": {Type: String, XValidations: extensionv1.ValidationRules{
+							{Rule: "self.startsWith('registry.example.com/')"},
+						}},
+					},
+				},
+			},
+		}},
+	}}
+
+	specRef := pschema.TypeSpec{Type: Object, Ref: "#/types/kubernetes:stable.example.com/v1:CronTabSpec"}
+	pkgSpec := &pschema.PackageSpec{
+		Resources: map[string]pschema.ResourceSpec{
+			"kubernetes:stable.example.com/v1:CronTab": {
+				ObjectTypeSpec:  pschema.ObjectTypeSpec{Properties: map[string]pschema.PropertySpec{"spec": {TypeSpec: specRef}}},
+				InputProperties: map[string]pschema.PropertySpec{"spec": {TypeSpec: specRef}},
+			},
+		},
+		Types: map[string]pschema.ComplexTypeSpec{
+			"kubernetes:stable.example.com/v1:CronTabSpec": {ObjectTypeSpec: pschema.ObjectTypeSpec{
+				Type: Object,
+				Properties: map[string]pschema.PropertySpec{
+					"replicas": {TypeSpec: pschema.TypeSpec{Type: Integer}, Description: "Number of replicas."},
+					"image":    {TypeSpec: pschema.TypeSpec{Type: String}, Description: "Image to run."},
+				},
+			}},
+		},
+	}
+	addConstraints(pkgSpec, crd)
+
+	properties := pkgSpec.Types["kubernetes:stable.example.com/v1:CronTabSpec"].Properties
+	if want, got := "Number of replicas.\n\nConstraints: minimum 1.", properties["replicas"].Description; got != want {
+		t.Errorf("expected replicas description %q, got %q", want, got)
+	}
+	if want, got := "Image to run.\n\nValidation rules:\n- `self.startsWith('registry.example.com/')`", properties["image"].Description; got != want {
+		t.Errorf("expected image description %q, got %q", want, got)
+	}
+	want := "Validation rules:\n- `self.replicas <= self.maxReplicas`: replicas must not exceed maxReplicas"
+	if got := pkgSpec.Types["kubernetes:stable.example.com/v1:CronTabSpec"].Description; got != want {
+		t.Errorf("expected spec description %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,413 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ConvertLanguages are the languages that Convert can write programs in.
+var ConvertLanguages = []string{NodeJS, Python, Go, YAML}
+
+// serverMetadataFields are the metadata fields set by the API server. They are dropped when converting manifests that
+// were exported from a cluster, since they can't be set by a program.
+var serverMetadataFields = []string{
+	"creationTimestamp", "deletionGracePeriodSeconds", "deletionTimestamp", "generation", "managedFields",
+	"resourceVersion", "selfLink", "uid",
+}
+
+// lastAppliedAnnotation is the annotation `kubectl apply` stores the applied manifest in.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// ConvertSettings configures the program written by Convert.
+type ConvertSettings struct {
+	// Language of the program, one of ConvertLanguages.
+	Language string
+	// PackageName is the name of the generated package. Defaults to DefaultName.
+	PackageName string
+	// SDK is what the program imports the generated package from: the NodeJS module, the Python module or the Go
+	// import path of the package's root. Defaults to "./<name>" for NodeJS, "pulumi_<name>" for Python and "<name>"
+	// for Go. It isn't used by YAML programs, which refer to resources by their type tokens.
+	SDK string
+}
+
+// convertResource is a custom resource read from a manifest.
+type convertResource struct {
+	token   string
+	group   string
+	version string
+	kind    string
+	// name is the logical name of the resource in the program.
+	name       string
+	properties []convertField
+}
+
+// convertField is a property of an object, or an entry of a map.
+type convertField struct {
+	name  string
+	value convertValue
+}
+
+type convertKind int
+
+const (
+	// convertUntyped values have no type in the package spec, and are written as plain literals.
+	convertUntyped convertKind = iota
+	convertPrimitive
+	convertEnum
+	convertObject
+	convertList
+	convertMap
+)
+
+// convertValue is a value read from a manifest, annotated with its type in the package spec.
+type convertValue struct {
+	kind     convertKind
+	typeSpec pschema.TypeSpec
+	// token is the type token of objects and enums.
+	token string
+	// fields are the properties of objects or the entries of maps, sorted by name.
+	fields []convertField
+	items  []convertValue
+	// value is the value of primitives, enums and untyped values.
+	value any
+}
+
+// converter converts manifests into resources, collecting a warning for everything it has to leave out.
+type converter struct {
+	pkgSpec *pschema.PackageSpec
+	// clusterScoped are the tokens of the cluster-scoped resources, whose namespaces are dropped.
+	clusterScoped map[string]bool
+	warnings      []string
+	// subject prefixes the warnings about the manifest being converted.
+	subject string
+}
+
+// Convert returns a Pulumi program in `settings.Language` that creates the custom resources in `manifests` with the
+// package generated from `pg`. Manifests are matched to resources by their apiVersion and kind, and their fields to
+// the properties of the resources and of the types they use. Manifests of other kinds, fields missing from the CRD
+// schemas and values of the wrong type are left out of the program and reported as warnings. Calling this function
+// will fully read and close each manifest.
+func Convert(pg *PackageGenerator, settings ConvertSettings, manifests []io.ReadCloser) ([]byte, []string, error) {
+	if !slices.Contains(ConvertLanguages, settings.Language) {
+		return nil, nil, fmt.Errorf("unsupported language %q, must be one of %q", settings.Language, ConvertLanguages)
+	}
+	if settings.PackageName == "" {
+		settings.PackageName = DefaultName
+	}
+
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not generate Pulumi package spec: %w", err)
+	}
+	objects, err := readManifests(manifests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &converter{pkgSpec: pkgSpec, clusterScoped: map[string]bool{}}
+	for _, crg := range pg.CustomResourceGenerators {
+		for _, version := range crg.Versions {
+			c.clusterScoped[getToken(crg.Group, version, crg.Kind)] = crg.IsClusterScoped()
+		}
+	}
+	resources := c.resources(objects)
+	if len(resources) == 0 {
+		return nil, c.warnings, errors.New("none of the manifests are custom resources defined by the CRDs")
+	}
+
+	var program []byte
+	switch settings.Language {
+	case NodeJS:
+		program, err = writeNodeJSProgram(pg, settings, resources)
+	case Python:
+		program, err = writePythonProgram(pg, settings, resources)
+	case Go:
+		program, err = writeGoProgram(pg, settings, resources)
+	case YAML:
+		program, err = writeYAMLProgram(resources)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not write %s program: %w", settings.Language, err)
+	}
+	return program, c.warnings, nil
+}
+
+// readManifests returns every object in `manifests`, with the items of lists such as `kubectl get -o yaml` output
+// expanded.
+func readManifests(manifests []io.ReadCloser) ([]map[string]any, error) {
+	var objects []map[string]any
+	for _, manifest := range manifests {
+		defer manifest.Close()
+		data, err := io.ReadAll(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+		dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 128)
+		for {
+			var object map[string]any
+			if err := dec.Decode(&object); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+			}
+			if items, ok := object["items"].([]any); ok && strings.HasSuffix(fmt.Sprint(object["kind"]), "List") {
+				for _, item := range items {
+					if item, ok := item.(map[string]any); ok {
+						objects = append(objects, item)
+					}
+				}
+				continue
+			}
+			if object != nil {
+				objects = append(objects, object)
+			}
+		}
+	}
+	return objects, nil
+}
+
+// resources converts the objects that are custom resources in the package, in order. Resources of the same kind and
+// name in different namespaces are told apart by prefixing their names with their namespaces, and any names that still
+// collide, e.g. of resources without a namespace, are numbered. The namespaces of
+// cluster-scoped resources are dropped with a warning, since the API server ignores them.
+func (c *converter) resources(objects []map[string]any) []convertResource {
+	var resources []convertResource
+	seen := map[string]bool{}
+	for _, object := range objects {
+		apiVersion, _ := object["apiVersion"].(string)
+		kind, _ := object["kind"].(string)
+		metadata, _ := object["metadata"].(map[string]any)
+		name, _ := metadata["name"].(string)
+		c.subject = fmt.Sprintf("%s %s: ", kind, name)
+
+		group, version, ok := strings.Cut(apiVersion, "/")
+		if !ok {
+			c.warn("", "skipped, %s %s is not a custom resource", apiVersion, kind)
+			continue
+		}
+		token := getToken(group, version, kind)
+		resource, ok := c.pkgSpec.Resources[token]
+		if !ok {
+			c.warn("", "skipped, %s %s is not defined by the CRDs", apiVersion, kind)
+			continue
+		}
+		if name == "" {
+			c.warn("", "skipped, metadata.name is required")
+			continue
+		}
+
+		namespace, _ := metadata["namespace"].(string)
+		if namespace != "" && c.clusterScoped[token] {
+			c.warn("metadata.namespace", "skipped, %s is cluster-scoped", kind)
+			namespace = ""
+		}
+		logicalName := name
+		if seen[token+"/"+logicalName] && namespace != "" {
+			logicalName = namespace + "-" + name
+		}
+		for i := 2; seen[token+"/"+logicalName]; i++ {
+			logicalName = fmt.Sprintf("%s-%d", name, i)
+			if namespace != "" {
+				logicalName = fmt.Sprintf("%s-%s-%d", namespace, name, i)
+			}
+		}
+		seen[token+"/"+logicalName] = true
+
+		properties := maps.Clone(object)
+		// The provider sets apiVersion and kind, and status is written by controllers.
+		delete(properties, "apiVersion")
+		delete(properties, "kind")
+		delete(properties, "status")
+		if metadata != nil {
+			clientMeta := clientMetadata(metadata)
+			if c.clusterScoped[token] {
+				delete(clientMeta, "namespace")
+			}
+			properties["metadata"] = clientMeta
+		}
+		resources = append(resources, convertResource{
+			token:      token,
+			group:      group,
+			version:    version,
+			kind:       kind,
+			name:       logicalName,
+			properties: c.fields(resource.InputProperties, properties, ""),
+		})
+	}
+	return resources
+}
+
+// clientMetadata returns `metadata` without the fields set by the API server.
+func clientMetadata(metadata map[string]any) map[string]any {
+	metadata = maps.Clone(metadata)
+	for _, field := range serverMetadataFields {
+		delete(metadata, field)
+	}
+	if annotations, ok := metadata["annotations"].(map[string]any); ok {
+		annotations = maps.Clone(annotations)
+		delete(annotations, lastAppliedAnnotation)
+		metadata["annotations"] = annotations
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+	return metadata
+}
+
+// fields converts the fields of an object with the given properties, sorted by name.
+func (c *converter) fields(properties map[string]pschema.PropertySpec, object map[string]any, path string) []convertField {
+	var fields []convertField
+	for _, name := range slices.Sorted(maps.Keys(object)) {
+		fieldPath := joinPath(path, name)
+		property, ok := properties[name]
+		if !ok {
+			c.warn(fieldPath, "skipped, not in the CRD schema")
+			continue
+		}
+		if value, ok := c.value(property.TypeSpec, object[name], fieldPath); ok {
+			fields = append(fields, convertField{name: name, value: value})
+		}
+	}
+	return fields
+}
+
+// value converts `v` to a value of type `typeSpec`. Returns false if `v` is null or doesn't have that type.
+func (c *converter) value(typeSpec pschema.TypeSpec, v any, path string) (convertValue, bool) {
+	if v == nil {
+		return convertValue{}, false
+	}
+	value := convertValue{kind: convertUntyped, typeSpec: typeSpec, value: v}
+
+	switch {
+	case typeSpec.Items != nil:
+		items, ok := v.([]any)
+		if !ok {
+			c.warn(path, "skipped, expected a list, got %s", jsonTypeName(v))
+			return value, false
+		}
+		value.kind = convertList
+		for i, item := range items {
+			if item, ok := c.value(*typeSpec.Items, item, fmt.Sprintf("%s[%d]", path, i)); ok {
+				value.items = append(value.items, item)
+			}
+		}
+	case typeSpec.AdditionalProperties != nil:
+		entries, ok := v.(map[string]any)
+		if !ok {
+			c.warn(path, "skipped, expected a map, got %s", jsonTypeName(v))
+			return value, false
+		}
+		value.kind = convertMap
+		for _, key := range slices.Sorted(maps.Keys(entries)) {
+			if entry, ok := c.value(*typeSpec.AdditionalProperties, entries[key], joinPath(path, key)); ok {
+				value.fields = append(value.fields, convertField{name: key, value: entry})
+			}
+		}
+	case strings.HasPrefix(typeSpec.Ref, typeRefPrefix):
+		token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+		typ, ok := c.pkgSpec.Types[token]
+		if !ok {
+			// Types defined by other packages are written as plain values.
+			return value, true
+		}
+		value.token = token
+		if len(typ.Enum) > 0 {
+			if !c.primitive(typ.Type, v, path) {
+				return value, false
+			}
+			value.kind = convertEnum
+			return value, true
+		}
+		object, ok := v.(map[string]any)
+		if !ok {
+			c.warn(path, "skipped, expected an object, got %s", jsonTypeName(v))
+			return value, false
+		}
+		value.kind = convertObject
+		value.fields = c.fields(typ.Properties, object, path)
+	case len(typeSpec.OneOf) == 0 && typeSpec.Ref == "" && typeSpec.Type != "" && typeSpec.Type != Object:
+		if !c.primitive(typeSpec.Type, v, path) {
+			return value, false
+		}
+		value.kind = convertPrimitive
+	}
+	return value, true
+}
+
+// primitive returns true if `v` is a value of the primitive type `typ`, and warns if it isn't.
+func (c *converter) primitive(typ string, v any, path string) bool {
+	var ok bool
+	switch typ {
+	case String:
+		_, ok = v.(string)
+	case Integer:
+		f, isNumber := v.(float64)
+		ok = isNumber && f == math.Trunc(f)
+	case Number:
+		_, ok = v.(float64)
+	case Boolean:
+		_, ok = v.(bool)
+	}
+	if !ok {
+		c.warn(path, "skipped, expected %s, got %s", typ, jsonTypeName(v))
+	}
+	return ok
+}
+
+func (c *converter) warn(path, format string, args ...any) {
+	if path != "" {
+		format = path + ": " + format
+	}
+	c.warnings = append(c.warnings, c.subject+fmt.Sprintf(format, args...))
+}
+
+// joinPath returns the path of the field `name` of the object at `path`.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonTypeName returns the JSON type of `v`, for error messages.
+func jsonTypeName(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,357 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const testManifests = `apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: my-crontab
+  namespace: jobs
+  uid: 2c1b4a0e-6f3d-4b8a-9f1e-6d2c3b4a5e6f
+  labels:
+    app: reports
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+spec:
+  cronSpec: "*/5 * * * *"
+  replicas: 2
+  policy: Forbid
+  has-hyphen: "yes"
+  args:
+    - value: 1
+    - value: two
+  unknown: true
+status:
+  lastRun: yesterday
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: stable.example.com/v1
+    kind: CronTab
+    metadata:
+      name: other
+    spec:
+      cronSpec: daily
+      replicas: many
+`
+
+// newConvertTestPackageGenerator returns the CronTab package, with an ObjectMeta type and a hyphenated property.
+func newConvertTestPackageGenerator() *PackageGenerator {
+	pg := newCronTabTestPackageGenerator()
+	pg.GroupVersions = []string{"stable.example.com/v1"}
+	pg.packageSpec.Types[objectMetaToken] = pschema.ComplexTypeSpec{
+		ObjectTypeSpec: pschema.ObjectTypeSpec{
+			Type: Object,
+			Properties: map[string]pschema.PropertySpec{
+				"name":        {TypeSpec: pschema.TypeSpec{Type: String}},
+				"namespace":   {TypeSpec: pschema.TypeSpec{Type: String}},
+				"labels":      {TypeSpec: pschema.TypeSpec{Type: Object, AdditionalProperties: &pschema.TypeSpec{Type: String}}},
+				"annotations": {TypeSpec: pschema.TypeSpec{Type: Object, AdditionalProperties: &pschema.TypeSpec{Type: String}}},
+				"uid":         {TypeSpec: pschema.TypeSpec{Type: String}},
+			},
+		},
+	}
+	spec := pg.packageSpec.Types["kubernetes:stable.example.com/v1:CronTabSpec"]
+	spec.Properties["has-hyphen"] = pschema.PropertySpec{TypeSpec: pschema.TypeSpec{Type: String}}
+	return pg
+}
+
+func convertTestManifests() []io.ReadCloser {
+	return []io.ReadCloser{io.NopCloser(strings.NewReader(testManifests))}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{language: NodeJS, want: `import * as crds from "./crds";
+
+const myCrontab = new crds.stable.v1.CronTab("my-crontab", {
+    metadata: {
+        labels: {
+            app: "reports",
+        },
+        name: "my-crontab",
+        namespace: "jobs",
+    },
+    spec: {
+        args: [
+            {
+                value: 1,
+            },
+            {
+                value: "two",
+            },
+        ],
+        cronSpec: "*/5 * * * *",
+        "has-hyphen": "yes",
+        policy: "Forbid",
+        replicas: 2,
+    },
+});
+
+const other = new crds.stable.v1.CronTab("other", {
+    metadata: {
+        name: "other",
+    },
+    spec: {
+        cronSpec: "daily",
+    },
+});
+`},
+		{language: Python, want: `import pulumi_kubernetes as k8s
+import pulumi_crds as crds
+
+my_crontab = crds.stable.v1.CronTab(
+    "my-crontab",
+    metadata=k8s.meta.v1.ObjectMetaArgs(
+        labels={
+            "app": "reports",
+        },
+        name="my-crontab",
+        namespace="jobs",
+    ),
+    spec=crds.stable.v1.CronTabSpecArgs(
+        args=[
+            crds.stable.v1.CronTabSpecArgsArgs(
+                value=1,
+            ),
+            crds.stable.v1.CronTabSpecArgsArgs(
+                value="two",
+            ),
+        ],
+        cron_spec="*/5 * * * *",
+        has_hyphen="yes",
+        policy=crds.stable.v1.CronTabSpecPolicy("Forbid"),
+        replicas=2,
+    ),
+)
+
+other = crds.stable.v1.CronTab(
+    "other",
+    metadata=k8s.meta.v1.ObjectMetaArgs(
+        name="other",
+    ),
+    spec=crds.stable.v1.CronTabSpecArgs(
+        cron_spec="daily",
+    ),
+)
+`},
+		{language: Go, want: `package main
+
+import (
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	stablev1 "crds/stable/v1"
+)
+
+func main() {
+	pulumi.Run(func(ctx *pulumi.Context) error {
+		if _, err := stablev1.NewCronTab(ctx, "my-crontab", &stablev1.CronTabArgs{
+			Metadata: &metav1.ObjectMetaArgs{
+				Labels: pulumi.StringMap{
+					"app": pulumi.String("reports"),
+				},
+				Name:      pulumi.String("my-crontab"),
+				Namespace: pulumi.String("jobs"),
+			},
+			Spec: &stablev1.CronTabSpecArgs{
+				Args: stablev1.CronTabSpecArgsArray{
+					stablev1.CronTabSpecArgsArgs{
+						Value: pulumi.Any(1),
+					},
+					stablev1.CronTabSpecArgsArgs{
+						Value: pulumi.Any("two"),
+					},
+				},
+				CronSpec:  pulumi.String("*/5 * * * *"),
+				HasHyphen: pulumi.String("yes"),
+				Policy:    stablev1.CronTabSpecPolicy("Forbid"),
+				Replicas:  pulumi.Int(2),
+			},
+		}); err != nil {
+			return err
+		}
+		if _, err := stablev1.NewCronTab(ctx, "other", &stablev1.CronTabArgs{
+			Metadata: &metav1.ObjectMetaArgs{
+				Name: pulumi.String("other"),
+			},
+			Spec: &stablev1.CronTabSpecArgs{
+				CronSpec: pulumi.String("daily"),
+			},
+		}); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+`},
+		{language: YAML, want: `resources:
+  my-crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      metadata:
+        labels:
+          app: reports
+        name: my-crontab
+        namespace: jobs
+      spec:
+        args:
+        - value: 1
+        - value: two
+        cronSpec: '*/5 * * * *'
+        has-hyphen: "yes"
+        policy: Forbid
+        replicas: 2
+  other:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      metadata:
+        name: other
+      spec:
+        cronSpec: daily
+`},
+	}
+	wantWarnings := []string{
+		"CronTab my-crontab: spec.unknown: skipped, not in the CRD schema",
+		"ConfigMap settings: skipped, v1 ConfigMap is not a custom resource",
+		"CronTab other: spec.replicas: skipped, expected integer, got string",
+	}
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			program, warnings, err := Convert(newConvertTestPackageGenerator(), ConvertSettings{Language: tt.language}, convertTestManifests())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(program); got != tt.want {
+				t.Errorf("expected program:\n%s\ngot:\n%s", tt.want, got)
+			}
+			if !slices.Equal(wantWarnings, warnings) {
+				t.Errorf("expected warnings %q, got %q", wantWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestConvertSDK(t *testing.T) {
+	program, _, err := Convert(newConvertTestPackageGenerator(), ConvertSettings{
+		Language: Go, PackageName: "cron-tabs", SDK: "example.com/infra/crds",
+	}, convertTestManifests())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `stablev1 "example.com/infra/crds/stable/v1"`; !strings.Contains(string(program), want) {
+		t.Errorf("expected program to import %s, got:\n%s", want, program)
+	}
+
+	program, _, err = Convert(newConvertTestPackageGenerator(), ConvertSettings{
+		Language: NodeJS, PackageName: "cron-tabs",
+	}, convertTestManifests())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `import * as cronTabs from "./cron-tabs";`; !strings.HasPrefix(string(program), want) {
+		t.Errorf("expected program to start with %s, got:\n%s", want, program)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	_, _, err := Convert(newConvertTestPackageGenerator(), ConvertSettings{Language: Java}, convertTestManifests())
+	if err == nil || !strings.Contains(err.Error(), `unsupported language "java"`) {
+		t.Errorf("expected an unsupported language error, got %v", err)
+	}
+
+	manifest := io.NopCloser(strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n"))
+	_, _, err = Convert(newConvertTestPackageGenerator(), ConvertSettings{Language: NodeJS}, []io.ReadCloser{manifest})
+	if err == nil || !strings.Contains(err.Error(), "none of the manifests are custom resources") {
+		t.Errorf("expected a no custom resources error, got %v", err)
+	}
+}
+
+func TestConvertClusterScoped(t *testing.T) {
+	pg := newConvertTestPackageGenerator()
+	pg.CustomResourceGenerators[1].Scope = extensionv1.ClusterScoped
+	schedule := pg.packageSpec.Resources["kubernetes:stable.example.com/v1:Schedule"]
+	schedule.InputProperties["metadata"] = pschema.PropertySpec{TypeSpec: pschema.TypeSpec{Type: Object, Ref: "#/types/" + objectMetaToken}}
+
+	manifest := io.NopCloser(strings.NewReader(`apiVersion: stable.example.com/v1
+kind: Schedule
+metadata:
+  name: nightly
+  namespace: jobs
+  labels:
+    app: reports
+`))
+	program, warnings, err := Convert(pg, ConvertSettings{Language: YAML}, []io.ReadCloser{manifest})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(program), "namespace") {
+		t.Errorf("expected the namespace to be dropped, got:\n%s", program)
+	}
+	if want := []string{"Schedule nightly: metadata.namespace: skipped, Schedule is cluster-scoped"}; !slices.Equal(want, warnings) {
+		t.Errorf("expected warnings %q, got %q", want, warnings)
+	}
+}
+
+func TestConvertLogicalNames(t *testing.T) {
+	pg := newConvertTestPackageGenerator()
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	crontab := func(name, namespace string) map[string]any {
+		metadata := map[string]any{"name": name}
+		if namespace != "" {
+			metadata["namespace"] = namespace
+		}
+		return map[string]any{"apiVersion": "stable.example.com/v1", "kind": "CronTab", "metadata": metadata}
+	}
+	c := &converter{pkgSpec: pkgSpec}
+	resources := c.resources([]map[string]any{
+		crontab("nightly", "jobs"),
+		crontab("nightly", "batch"),
+		crontab("nightly", ""),
+		crontab("nightly", ""),
+		crontab("nightly", "jobs"),
+		crontab("nightly", "jobs"),
+	})
+	var names []string
+	for _, r := range resources {
+		names = append(names, r.name)
+	}
+	want := []string{"nightly", "batch-nightly", "nightly-2", "nightly-3", "jobs-nightly", "jobs-nightly-2"}
+	if !slices.Equal(want, names) {
+		t.Errorf("expected names %q, got %q", want, names)
+	}
+}
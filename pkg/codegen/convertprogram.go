@@ -0,0 +1,545 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/cgstrings"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// goKubernetesMetaImport is the Go package of the ObjectMeta types in the Kubernetes provider's SDK.
+const goKubernetesMetaImport = "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+
+// maxInlineList is the length up to which lists of primitives are written on a single line.
+const maxInlineList = 60
+
+var (
+	jsIdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	nonAlphanumeric   = regexp.MustCompile(`[^A-Za-z0-9]+`)
+	yamlPlainKeyRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+	nodejsReservedWords = []string{
+		"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do", "else", "enum",
+		"export", "extends", "false", "finally", "for", "function", "if", "import", "in", "instanceof", "new", "null",
+		"return", "super", "switch", "this", "throw", "true", "try", "typeof", "var", "void", "while", "with", "yield",
+		"let", "static", "implements", "interface", "package", "private", "protected", "public", "await",
+	}
+	pythonKeywords = []string{
+		"False", "None", "True", "and", "as", "assert", "async", "await", "break", "class", "continue", "def", "del",
+		"elif", "else", "except", "finally", "for", "from", "global", "if", "import", "in", "is", "lambda", "nonlocal",
+		"not", "or", "pass", "raise", "return", "try", "while", "with", "yield",
+	}
+)
+
+// splitToken returns the <group>/<version> and name of a type or resource token.
+func splitToken(token string) (string, string) {
+	groupVersion, name, _ := strings.Cut(strings.TrimPrefix(token, "kubernetes:"), ":")
+	return groupVersion, name
+}
+
+// identifiers hands out unique variable names for resources.
+type identifiers struct {
+	used map[string]bool
+}
+
+func newIdentifiers(reserved ...string) *identifiers {
+	used := map[string]bool{}
+	for _, word := range reserved {
+		used[word] = true
+	}
+	return &identifiers{used: used}
+}
+
+// add returns `name`, or `name` followed by a number if it's already used.
+func (ids *identifiers) add(name string) string {
+	unique := name
+	for i := 2; ids.used[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	ids.used[unique] = true
+	return unique
+}
+
+// variableWords returns the words of the variable holding a resource named `name`, prefixed by its kind if the name
+// doesn't start with a letter.
+func variableWords(name, kind string) []string {
+	words := strings.Fields(nonAlphanumeric.ReplaceAllString(name, " "))
+	if len(words) == 0 || !unicode.IsLetter(rune(words[0][0])) {
+		words = append([]string{kind}, words...)
+	}
+	return words
+}
+
+// camelCase joins `words` in lower camel case, e.g. myCronTab.
+func camelCase(words []string) string {
+	var b strings.Builder
+	for i, word := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word[:1]) + word[1:])
+			continue
+		}
+		b.WriteString(cgstrings.UppercaseFirst(word))
+	}
+	return b.String()
+}
+
+// goName returns the Go name of a struct field, e.g. CronSpec for cronSpec and HasAHyphen for has-a-hyphen.
+func goName(name string) string {
+	return cgstrings.UppercaseFirst(cgstrings.Unhyphenate(name))
+}
+
+// untypedValue returns `v` as a value with no type, so that it's written as a plain literal.
+func untypedValue(v any) convertValue {
+	switch v := v.(type) {
+	case []any:
+		value := convertValue{kind: convertList}
+		for _, item := range v {
+			value.items = append(value.items, untypedValue(item))
+		}
+		return value
+	case map[string]any:
+		value := convertValue{kind: convertMap}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			value.fields = append(value.fields, convertField{name: key, value: untypedValue(v[key])})
+		}
+		return value
+	}
+	return convertValue{kind: convertPrimitive, value: v}
+}
+
+// jsonLiteral returns `v` as JSON, which is also a valid JavaScript or Python literal for strings and numbers.
+func jsonLiteral(v any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// inlineList returns the items of a list of primitives joined on a single line, or false if the list has other values
+// or would be too long.
+func inlineList(items []string, values []convertValue) (string, bool) {
+	for _, value := range values {
+		if value.kind != convertPrimitive && value.kind != convertEnum {
+			return "", false
+		}
+	}
+	line := strings.Join(items, ", ")
+	return line, len(line) <= maxInlineList && !strings.Contains(line, "\n")
+}
+
+// writeBlock writes `entries` one per line between `open` and `close`, indented one level more than `indent`.
+func writeBlock(open, close string, entries []string, indent string) string {
+	if len(entries) == 0 {
+		return open + close
+	}
+	var b strings.Builder
+	b.WriteString(open + "\n")
+	for _, entry := range entries {
+		b.WriteString(indent + "    " + entry + ",\n")
+	}
+	b.WriteString(indent + close)
+	return b.String()
+}
+
+// nodejsWriter writes the values of a TypeScript program as object literals, which the SDK's input types accept.
+type nodejsWriter struct{}
+
+func (w nodejsWriter) value(v convertValue, indent string) string {
+	switch v.kind {
+	case convertUntyped:
+		return w.value(untypedValue(v.value), indent)
+	case convertObject, convertMap:
+		return w.object(v.fields, indent)
+	case convertList:
+		items := make([]string, 0, len(v.items))
+		for _, item := range v.items {
+			items = append(items, w.value(item, indent+"    "))
+		}
+		if line, ok := inlineList(items, v.items); ok {
+			return "[" + line + "]"
+		}
+		return writeBlock("[", "]", items, indent)
+	}
+	if v.value == nil {
+		return "null"
+	}
+	return jsonLiteral(v.value)
+}
+
+func (w nodejsWriter) object(fields []convertField, indent string) string {
+	entries := make([]string, 0, len(fields))
+	for _, field := range fields {
+		key := field.name
+		if !jsIdentifierRegex.MatchString(key) {
+			key = jsonLiteral(key)
+		}
+		entries = append(entries, key+": "+w.value(field.value, indent+"    "))
+	}
+	return writeBlock("{", "}", entries, indent)
+}
+
+// writeNodeJSProgram writes a TypeScript program creating `resources` with the NodeJS SDK.
+func writeNodeJSProgram(pg *PackageGenerator, settings ConvertSettings, resources []convertResource) ([]byte, error) {
+	modules, err := pg.languageModules("")
+	if err != nil {
+		return nil, err
+	}
+	sdk := settings.SDK
+	if sdk == "" {
+		sdk = "./" + settings.PackageName
+	}
+	alias := camelCase(variableWords(settings.PackageName, "crds"))
+	variables := newIdentifiers(append(slices.Clone(nodejsReservedWords), alias)...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "import * as %s from %s;\n", alias, jsonLiteral(sdk))
+	for _, r := range resources {
+		module := strings.ReplaceAll(modules[r.group+"/"+r.version], "/", ".")
+		fmt.Fprintf(&b, "\nconst %s = new %s.%s.%s(%s, %s);\n",
+			variables.add(camelCase(variableWords(r.name, r.kind))), alias, module, r.kind, jsonLiteral(r.name),
+			nodejsWriter{}.object(r.properties, ""))
+	}
+	return []byte(b.String()), nil
+}
+
+// pythonWriter writes the values of a Python program, using the SDK's args classes for objects.
+type pythonWriter struct {
+	modules map[string]string
+	alias   string
+	// usesKubernetes is set once a type from the Kubernetes provider's SDK is written.
+	usesKubernetes bool
+}
+
+// typeName returns the qualified name of the class or enum of a type token.
+func (w *pythonWriter) typeName(token string) string {
+	groupVersion, name := splitToken(token)
+	alias := w.alias
+	if groupVersion == "meta/v1" {
+		alias = "k8s"
+		w.usesKubernetes = true
+	}
+	return alias + "." + strings.ReplaceAll(w.modules[groupVersion], "/", ".") + "." + name
+}
+
+func (w *pythonWriter) value(v convertValue, indent string) string {
+	switch v.kind {
+	case convertUntyped:
+		return w.value(untypedValue(v.value), indent)
+	case convertObject:
+		return writeBlock(w.typeName(v.token)+"Args(", ")", w.arguments(v.fields, indent), indent)
+	case convertMap:
+		entries := make([]string, 0, len(v.fields))
+		for _, field := range v.fields {
+			entries = append(entries, jsonLiteral(field.name)+": "+w.value(field.value, indent+"    "))
+		}
+		return writeBlock("{", "}", entries, indent)
+	case convertList:
+		items := make([]string, 0, len(v.items))
+		for _, item := range v.items {
+			items = append(items, w.value(item, indent+"    "))
+		}
+		if line, ok := inlineList(items, v.items); ok {
+			return "[" + line + "]"
+		}
+		return writeBlock("[", "]", items, indent)
+	case convertEnum:
+		return w.typeName(v.token) + "(" + jsonLiteral(v.value) + ")"
+	}
+	switch v.value {
+	case nil:
+		return "None"
+	case true:
+		return "True"
+	case false:
+		return "False"
+	}
+	return jsonLiteral(v.value)
+}
+
+// arguments returns the keyword arguments setting `fields`.
+func (w *pythonWriter) arguments(fields []convertField, indent string) []string {
+	arguments := make([]string, 0, len(fields))
+	for _, field := range fields {
+		arguments = append(arguments, python.InitParamName(field.name)+"="+w.value(field.value, indent+"    "))
+	}
+	return arguments
+}
+
+// writePythonProgram writes a Python program creating `resources` with the Python SDK.
+func writePythonProgram(pg *PackageGenerator, settings ConvertSettings, resources []convertResource) ([]byte, error) {
+	modules, err := pg.languageModules("")
+	if err != nil {
+		return nil, err
+	}
+	sdk := settings.SDK
+	if sdk == "" {
+		sdk = "pulumi_" + settings.PackageName
+	}
+	w := &pythonWriter{modules: modules, alias: python.PyName(strings.Join(variableWords(settings.PackageName, "crds"), "_"))}
+	variables := newIdentifiers(append(slices.Clone(pythonKeywords), w.alias, "k8s", "pulumi")...)
+
+	var body strings.Builder
+	for _, r := range resources {
+		arguments := append([]string{jsonLiteral(r.name)}, w.arguments(r.properties, "")...)
+		fmt.Fprintf(&body, "\n%s = %s\n", variables.add(python.PyName(strings.Join(variableWords(r.name, r.kind), "_"))),
+			writeBlock(w.alias+"."+strings.ReplaceAll(modules[r.group+"/"+r.version], "/", ".")+"."+r.kind+"(", ")", arguments, ""))
+	}
+
+	var b strings.Builder
+	if w.usesKubernetes {
+		b.WriteString("import pulumi_kubernetes as k8s\n")
+	}
+	fmt.Fprintf(&b, "import %s as %s\n", sdk, w.alias)
+	b.WriteString(body.String())
+	return []byte(b.String()), nil
+}
+
+// goWriter writes the values of a Go program, using the SDK's input types.
+type goWriter struct {
+	modules map[string]string
+	// imports maps the alias of every SDK package that's used to its import path.
+	imports map[string]string
+	sdk     string
+	types   map[string]pschema.ComplexTypeSpec
+}
+
+// qualifier returns the alias of the package of <group>/<version>, importing it.
+func (w *goWriter) qualifier(groupVersion string) string {
+	module := w.modules[groupVersion]
+	alias := strings.ToLower(nonAlphanumeric.ReplaceAllString(module, ""))
+	if groupVersion == "meta/v1" {
+		w.imports[alias] = goKubernetesMetaImport
+	} else {
+		w.imports[alias] = w.sdk + "/" + module
+	}
+	return alias
+}
+
+// typeName returns the qualified name of the type of a type token.
+func (w *goWriter) typeName(token string) string {
+	groupVersion, name := splitToken(token)
+	return w.qualifier(groupVersion) + "." + name
+}
+
+// inputType returns the name of the input type of values of type `typeSpec`, e.g. pulumi.StringArray.
+func (w *goWriter) inputType(typeSpec pschema.TypeSpec) string {
+	collection := func(element pschema.TypeSpec, suffix string) string {
+		if name := w.inputType(element); name != "pulumi.Any" {
+			return name + suffix
+		}
+		return "pulumi." + suffix
+	}
+	switch {
+	case typeSpec.Items != nil:
+		return collection(*typeSpec.Items, "Array")
+	case typeSpec.AdditionalProperties != nil:
+		return collection(*typeSpec.AdditionalProperties, "Map")
+	case strings.HasPrefix(typeSpec.Ref, typeRefPrefix):
+		token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+		if _, ok := w.types[token]; ok {
+			return w.typeName(token)
+		}
+		return "pulumi.Any"
+	case len(typeSpec.OneOf) > 0 || typeSpec.Ref != "":
+		return "pulumi.Any"
+	}
+	switch typeSpec.Type {
+	case String:
+		return "pulumi.String"
+	case Integer:
+		return "pulumi.Int"
+	case Number:
+		return "pulumi.Float64"
+	case Boolean:
+		return "pulumi.Bool"
+	}
+	return "pulumi.Any"
+}
+
+// value returns `v` as a Go expression. Objects are pointers unless they're `elements` of a collection.
+func (w *goWriter) value(v convertValue, element bool) string {
+	switch v.kind {
+	case convertUntyped:
+		return "pulumi.Any(" + goLiteral(v.value) + ")"
+	case convertObject:
+		literal := w.typeName(v.token) + "Args" + w.fields(v.fields)
+		if element {
+			return literal
+		}
+		return "&" + literal
+	case convertMap:
+		entries := make([]string, 0, len(v.fields))
+		for _, field := range v.fields {
+			entries = append(entries, strconv.Quote(field.name)+": "+w.value(field.value, true))
+		}
+		return writeBlock(w.inputType(v.typeSpec)+"{", "}", entries, "")
+	case convertList:
+		items := make([]string, 0, len(v.items))
+		for _, item := range v.items {
+			items = append(items, w.value(item, true))
+		}
+		if line, ok := inlineList(items, v.items); ok {
+			return w.inputType(v.typeSpec) + "{" + line + "}"
+		}
+		return writeBlock(w.inputType(v.typeSpec)+"{", "}", items, "")
+	case convertEnum:
+		return w.typeName(v.token) + "(" + goLiteral(v.value) + ")"
+	}
+	return w.inputType(v.typeSpec) + "(" + goLiteral(v.value) + ")"
+}
+
+// fields returns the body of a struct literal setting `fields`.
+func (w *goWriter) fields(fields []convertField) string {
+	entries := make([]string, 0, len(fields))
+	for _, field := range fields {
+		entries = append(entries, goName(field.name)+": "+w.value(field.value, false))
+	}
+	return writeBlock("{", "}", entries, "")
+}
+
+// goLiteral returns `v` as a Go literal of an untyped value.
+func goLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, goLiteral(item))
+		}
+		return writeBlock("[]interface{}{", "}", items, "")
+	case map[string]any:
+		entries := make([]string, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			entries = append(entries, strconv.Quote(key)+": "+goLiteral(v[key]))
+		}
+		return writeBlock("map[string]interface{}{", "}", entries, "")
+	}
+	return jsonLiteral(v)
+}
+
+// writeGoProgram writes a Go program creating `resources` with the Go SDK. The program is formatted with gofmt.
+func writeGoProgram(pg *PackageGenerator, settings ConvertSettings, resources []convertResource) ([]byte, error) {
+	modules, err := pg.languageModules("")
+	if err != nil {
+		return nil, err
+	}
+	sdk := settings.SDK
+	if sdk == "" {
+		sdk = settings.PackageName
+	}
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		return nil, err
+	}
+	w := &goWriter{modules: modules, imports: map[string]string{}, sdk: sdk, types: pkgSpec.Types}
+
+	var body strings.Builder
+	for _, r := range resources {
+		qualifier := w.qualifier(r.group + "/" + r.version)
+		fmt.Fprintf(&body, "if _, err := %s.New%s(ctx, %s, &%s.%sArgs%s); err != nil {\nreturn err\n}\n",
+			qualifier, r.kind, strconv.Quote(r.name), qualifier, r.kind, w.fields(r.properties))
+	}
+
+	var b strings.Builder
+	b.WriteString("package main\n\nimport (\n")
+	b.WriteString("\t\"github.com/pulumi/pulumi/sdk/v3/go/pulumi\"\n")
+	if _, ok := w.imports["metav1"]; ok {
+		fmt.Fprintf(&b, "\tmetav1 %q\n", goKubernetesMetaImport)
+	}
+	b.WriteString("\n")
+	for _, alias := range slices.Sorted(maps.Keys(w.imports)) {
+		if alias != "metav1" {
+			fmt.Fprintf(&b, "\t%s %q\n", alias, w.imports[alias])
+		}
+	}
+	b.WriteString(")\n\nfunc main() {\npulumi.Run(func(ctx *pulumi.Context) error {\n")
+	b.WriteString(body.String())
+	b.WriteString("return nil\n})\n}\n")
+
+	program, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("could not format Go program: %w", err)
+	}
+	return program, nil
+}
+
+// writeYAMLProgram writes the resources section of a Pulumi YAML program creating `resources`, which can be used as
+// the project's Main.yaml.
+func writeYAMLProgram(resources []convertResource) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("resources:\n")
+	for _, r := range resources {
+		properties, err := yaml.Marshal(yamlProgramValue(convertValue{kind: convertObject, fields: r.properties}))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "  %s:\n    type: %s\n", yamlKey(r.name), r.token)
+		if len(r.properties) == 0 {
+			continue
+		}
+		b.WriteString("    properties:\n")
+		for _, line := range strings.SplitAfter(strings.TrimSuffix(string(properties), "\n"), "\n") {
+			b.WriteString("      " + line)
+		}
+		b.WriteString("\n")
+	}
+	return []byte(b.String()), nil
+}
+
+// yamlKey returns `key` quoted if it isn't a plain YAML scalar.
+func yamlKey(key string) string {
+	if yamlPlainKeyRegex.MatchString(key) {
+		return key
+	}
+	return jsonLiteral(key)
+}
+
+// yamlProgramValue returns `v` as plain data, with the property names of the manifest.
+func yamlProgramValue(v convertValue) any {
+	switch v.kind {
+	case convertObject, convertMap:
+		object := make(map[string]any, len(v.fields))
+		for _, field := range v.fields {
+			object[field.name] = yamlProgramValue(field.value)
+		}
+		return object
+	case convertList:
+		items := make([]any, 0, len(v.items))
+		for _, item := range v.items {
+			items = append(items, yamlProgramValue(item))
+		}
+		return items
+	}
+	return v.value
+}
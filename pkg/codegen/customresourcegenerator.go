@@ -44,6 +44,9 @@ type CustomResourceGenerator struct {
 	Plural string
 	// Group represents the `spec.group` field in the CRD YAML
 	Group string
+	// Scope represents the `spec.scope` field in the CRD YAML, either
+	// Namespaced or Cluster
+	Scope extensionv1.ResourceScope
 	// Versions is a slice of names of each version supported by this CRD
 	Versions []string
 	// GroupVersions is a slice of names of each version, in the format
@@ -52,6 +55,12 @@ type CustomResourceGenerator struct {
 	// ResourceTokens is a slice of the token types of every versioned
 	// CustomResource
 	ResourceTokens []string
+	// readOnly maps each version to its top-level properties marked
+	// `readOnly: true`
+	readOnly map[string][]string
+	// nestedReadOnly maps each version to the JSON paths of its nested
+	// properties marked `readOnly: true`, which are reported as diagnostics
+	nestedReadOnly map[string][]string
 }
 
 // flattenOpenAPI recursively finds all nested objects in the OpenAPI spec and flattens them into a single object as definitions.
@@ -135,7 +144,8 @@ func flattenRecursively(sw *spec.Swagger, parentName string, currSpec spec.Schem
 
 	// Recurse through the properties of the object.
 	for nestedPropertyName, nestedProperty := range currSpec.Properties {
-		// VistoriaMetrics has some weird fields - likely a typegen issue on their end, so let's skip them.
+		// VistoriaMetrics has some weird fields - likely a typegen issue on their end, so let's skip them. They are
+		// reported as dropped by schemaDiagnostics.
 		if nestedPropertyName == "-" {
 			delete(currSpec.Properties, nestedPropertyName)
 			continue
@@ -220,7 +230,8 @@ func crdToOpenAPI(crd *extensionv1.CustomResourceDefinition) (map[string]*spec.S
 	setCRDDefaults(crd)
 
 	for _, v := range crd.Spec.Versions {
-		// Defaults are not pruned here, but before being served.
+		// Defaults are not pruned here, but before being served. Value validations such as enums are stripped, since
+		// the Pulumi schema generator doesn't support them; enums are added back by addEnums.
 		sw, err := builder.BuildOpenAPIV2(crd, v.Name, builder.Options{V2: true, StripValueValidation: true, StripNullable: true, AllowNonStructural: true, IncludeSelectableFields: true})
 		if err != nil {
 			return nil, err
@@ -265,6 +276,7 @@ func NewCustomResourceGenerator(crd extensionv1.CustomResourceDefinition) (Custo
 	kind := crd.Spec.Names.Kind
 	plural := crd.Spec.Names.Plural
 	group := crd.Spec.Group
+	scope := crd.Spec.Scope
 
 	versions := make([]string, 0, len(schemas))
 	groupVersions := make([]string, 0, len(schemas))
@@ -282,6 +294,7 @@ func NewCustomResourceGenerator(crd extensionv1.CustomResourceDefinition) (Custo
 		Kind:                     kind,
 		Plural:                   plural,
 		Group:                    group,
+		Scope:                    scope,
 		Versions:                 versions,
 		GroupVersions:            groupVersions,
 		ResourceTokens:           resourceTokens,
@@ -290,6 +303,12 @@ func NewCustomResourceGenerator(crd extensionv1.CustomResourceDefinition) (Custo
 	return crg, nil
 }
 
+// IsClusterScoped returns true if the CustomResource is cluster-scoped, and so
+// has no namespace.
+func (crg *CustomResourceGenerator) IsClusterScoped() bool {
+	return crg.Scope == extensionv1.ClusterScoped
+}
+
 // HasSchemas returns true if the CustomResource specifies at least some schema, and false otherwise.
 func (crg *CustomResourceGenerator) HasSchemas() bool {
 	return len(crg.Schemas) > 0
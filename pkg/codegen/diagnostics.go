@@ -0,0 +1,195 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Diagnostic describes a field of a CRD schema that the generated code can't represent exactly: it is typed as any,
+// losing its type in every language, dropped, or read-only but settable.
+type Diagnostic struct {
+	// CRD is the name of the CRD, e.g. "certificates.cert-manager.io".
+	CRD string
+	// Version is the name of the CRD version, e.g. "v1".
+	Version string
+	// Path is the JSON path of the field, e.g. ".spec.solvers[*].config". Items of arrays are [*] and values of maps
+	// are .*.
+	Path string
+	// Reason describes how the field is converted.
+	Reason string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s %s: %s: %s", d.CRD, d.Version, d.Path, d.Reason)
+}
+
+// schemaDiagnostics returns the lossy conversions of every version of `crd` in `pkgSpec`, the final package spec: the
+// properties of its schemas that are missing from the generated types, and those typed as any, unless their schemas
+// preserve unknown fields. The metadata field is replaced by ObjectMeta, so it is never reported.
+func schemaDiagnostics(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, v := range crd.Spec.Versions {
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		resource, ok := pkgSpec.Resources[getToken(crd.Spec.Group, v.Name, crd.Spec.Names.Kind)]
+		if !ok {
+			continue
+		}
+		d := &diagnoser{
+			pkgSpec: pkgSpec,
+			scope:   getToken(crd.Spec.Group, v.Name, ""),
+			visited: map[string]bool{},
+			report: func(path, reason string) {
+				diagnostics = append(diagnostics, Diagnostic{CRD: crd.Name, Version: v.Name, Path: path, Reason: reason})
+			},
+		}
+		// The outputs of a resource include the output-only properties, such as status.
+		d.properties(resource.Properties, *v.Schema.OpenAPIV3Schema, "")
+	}
+	return sortDiagnostics(crd, diagnostics)
+}
+
+// diagnoser compares the types of a version of a CRD in a package spec with its schema.
+type diagnoser struct {
+	pkgSpec *pschema.PackageSpec
+	// scope is the token prefix of the types generated for the version, so shared types such as ObjectMeta aren't
+	// followed.
+	scope string
+	// visited are the tokens of the types already compared, each only once.
+	visited map[string]bool
+	report  func(path, reason string)
+}
+
+// properties reports the properties of `schema`, the schema of the object at `path`, that are missing from
+// `properties` or lose their types.
+func (d *diagnoser) properties(properties map[string]pschema.PropertySpec, schema extensionv1.JSONSchemaProps, path string) {
+	schemaProperties := combinedProperties(schema)
+	for _, name := range slices.Sorted(maps.Keys(schemaProperties)) {
+		if path == "" && name == "metadata" {
+			continue
+		}
+		propertyPath := path + "." + name
+		property, ok := properties[name]
+		if !ok {
+			if strcase.ToCamel(name) == "" {
+				d.report(propertyPath, "property name has no letters or digits, so the property is dropped")
+			} else {
+				d.report(propertyPath, "property is dropped")
+			}
+			continue
+		}
+		d.typeSpec(property.TypeSpec, schemaProperties[name], propertyPath)
+	}
+}
+
+// typeSpec reports the parts of `typeSpec`, the type of the field at `path`, that lose the types of `schema`.
+func (d *diagnoser) typeSpec(typeSpec pschema.TypeSpec, schema extensionv1.JSONSchemaProps, path string) {
+	switch {
+	case isAnyType(typeSpec):
+		if schema.XPreserveUnknownFields == nil || !*schema.XPreserveUnknownFields {
+			d.report(path, anyTypeReason(schema))
+		}
+	case typeSpec.Items != nil:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			if isAnyType(*typeSpec.Items) {
+				d.report(path+"[*]", "no schema, so it is typed as any")
+			}
+			return
+		}
+		d.typeSpec(*typeSpec.Items, *schema.Items.Schema, path+"[*]")
+	case typeSpec.AdditionalProperties != nil:
+		// Objects without additionalProperties schemas are arbitrary JSON, which is what their schemas allow.
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			d.typeSpec(*typeSpec.AdditionalProperties, *schema.AdditionalProperties.Schema, path+".*")
+		}
+	case strings.HasPrefix(typeSpec.Ref, typeRefPrefix+d.scope):
+		token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+		typ, ok := d.pkgSpec.Types[token]
+		if !ok || d.visited[token] || len(typ.Enum) > 0 {
+			return
+		}
+		d.visited[token] = true
+		d.properties(typ.Properties, schema, path)
+	}
+}
+
+// anyTypeReason returns why a field with `schema` is typed as any.
+func anyTypeReason(schema extensionv1.JSONSchemaProps) string {
+	for i, branch := range schema.OneOf {
+		if !representable(branch) {
+			return fmt.Sprintf("oneOf branch %d has no representable type, so the union is typed as any", i)
+		}
+	}
+	switch {
+	case len(schema.OneOf) > 0:
+		return "oneOf union has no representable type, so it is typed as any"
+	case schema.Type == "":
+		return "schema has no type, so it is typed as any"
+	case !slices.Contains(schemaTypes, schema.Type):
+		return fmt.Sprintf("unsupported type %q, so it is typed as any", schema.Type)
+	}
+	return "typed as any"
+}
+
+// schemaTypes are the OpenAPI types with a Pulumi type.
+var schemaTypes = []string{Boolean, Integer, Number, String, Array, Object}
+
+// representable returns whether `schema` has a type with a Pulumi type, or combines schemas that may have one.
+func representable(schema extensionv1.JSONSchemaProps) bool {
+	if schema.Type != "" {
+		return slices.Contains(schemaTypes, schema.Type)
+	}
+	return schema.XIntOrString || len(schema.Properties) > 0 || len(schema.AllOf) > 0 || len(schema.AnyOf) > 0 ||
+		len(schema.OneOf) > 0
+}
+
+// combinedProperties returns the properties of `schema`, along with those of the schemas it combines with allOf and
+// anyOf, like the generated types.
+func combinedProperties(schema extensionv1.JSONSchemaProps) map[string]extensionv1.JSONSchemaProps {
+	if len(schema.AllOf) == 0 && len(schema.AnyOf) == 0 {
+		return schema.Properties
+	}
+	properties := maps.Clone(schema.Properties)
+	if properties == nil {
+		properties = map[string]extensionv1.JSONSchemaProps{}
+	}
+	for _, combined := range slices.Concat(schema.AllOf, schema.AnyOf) {
+		maps.Copy(properties, combinedProperties(combined))
+	}
+	return properties
+}
+
+// sortDiagnostics sorts the diagnostics of `crd` in the order of its versions and then by path.
+func sortDiagnostics(crd extensionv1.CustomResourceDefinition, diagnostics []Diagnostic) []Diagnostic {
+	version := func(d Diagnostic) int {
+		return slices.IndexFunc(crd.Spec.Versions, func(v extensionv1.CustomResourceDefinitionVersion) bool { return v.Name == d.Version })
+	}
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		if c := version(a) - version(b); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	return diagnostics
+}
@@ -0,0 +1,129 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"reflect"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestSchemaDiagnostics(t *testing.T) {
+	preserveUnknownFields := true
+	crd := filterTestCRD("stable.example.com", "CronTab", "v1", "v1beta1")
+	crd.Spec.Versions[0].Schema = &extensionv1.CustomResourceValidation{
+		OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+			Type: Object,
+			Properties: map[string]extensionv1.JSONSchemaProps{
+				"metadata": {},
+				"spec": {
+					Type: Object,
+					Properties: map[string]extensionv1.JSONSchemaProps{
+						"-":        {Type: String},
+						"config":   {Description: "untyped"},
+						"image":    {Type: String},
+						"renamed":  {Type: String},
+						"port":     {XIntOrString: true},
+						"extra":    {Type: Object, XPreserveUnknownFields: &preserveUnknownFields},
+						"schedule": {OneOf: []extensionv1.JSONSchemaProps{{Type: String}, {Description: "untyped"}}},
+						"volumes": {Type: Array, Items: &extensionv1.JSONSchemaPropsOrArray{Schema: &extensionv1.JSONSchemaProps{
+							Type: Object,
+							Properties: map[string]extensionv1.JSONSchemaProps{
+								"file": {Type: "file"},
+							},
+						}}},
+						"labels": {Type: Object, AdditionalProperties: &extensionv1.JSONSchemaPropsOrBool{
+							Schema: &extensionv1.JSONSchemaProps{},
+						}},
+					},
+				},
+			},
+		},
+	}
+	crd.Spec.Versions[1].Schema = &extensionv1.CustomResourceValidation{
+		OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+			Type: Object,
+			Properties: map[string]extensionv1.JSONSchemaProps{
+				"spec": {Type: Object, Properties: map[string]extensionv1.JSONSchemaProps{"args": {Type: Array}}},
+			},
+		},
+	}
+
+	// The package spec as generated from the schemas, with "-" and "renamed" missing.
+	ref := func(token string) pschema.TypeSpec {
+		return pschema.TypeSpec{Type: Object, Ref: typeRefPrefix + "kubernetes:stable.example.com/" + token}
+	}
+	resource := func(spec pschema.TypeSpec) pschema.ResourceSpec {
+		return pschema.ResourceSpec{ObjectTypeSpec: pschema.ObjectTypeSpec{Properties: map[string]pschema.PropertySpec{
+			"metadata": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: objectMetaRef}},
+			"spec":     {TypeSpec: spec},
+		}}}
+	}
+	pkgSpec := &pschema.PackageSpec{
+		Resources: map[string]pschema.ResourceSpec{
+			"kubernetes:stable.example.com/v1:CronTab":      resource(ref("v1:CronTabSpec")),
+			"kubernetes:stable.example.com/v1beta1:CronTab": resource(ref("v1beta1:CronTabSpec")),
+		},
+		Types: map[string]pschema.ComplexTypeSpec{
+			"kubernetes:stable.example.com/v1:CronTabSpec": {ObjectTypeSpec: pschema.ObjectTypeSpec{Properties: map[string]pschema.PropertySpec{
+				"config":   {TypeSpec: anyTypeSpec},
+				"image":    {TypeSpec: pschema.TypeSpec{Type: String}},
+				"port":     {TypeSpec: intOrStringTypeSpec},
+				"extra":    {TypeSpec: arbitraryJSONTypeSpec},
+				"schedule": {TypeSpec: anyTypeSpec},
+				"volumes":  {TypeSpec: pschema.TypeSpec{Type: Array, Items: &pschema.TypeSpec{Type: Object, Ref: ref("v1:CronTabSpecVolumes").Ref}}},
+				"labels":   {TypeSpec: pschema.TypeSpec{Type: Object, AdditionalProperties: &anyTypeSpec}},
+			}}},
+			"kubernetes:stable.example.com/v1:CronTabSpecVolumes": {ObjectTypeSpec: pschema.ObjectTypeSpec{Properties: map[string]pschema.PropertySpec{
+				"file": {TypeSpec: anyTypeSpec},
+			}}},
+			"kubernetes:stable.example.com/v1beta1:CronTabSpec": {ObjectTypeSpec: pschema.ObjectTypeSpec{Properties: map[string]pschema.PropertySpec{
+				"args": {TypeSpec: pschema.TypeSpec{Type: Array, Items: &anyTypeSpec}},
+			}}},
+		},
+	}
+
+	diagnostics := schemaDiagnostics(pkgSpec, crd)
+	want := []Diagnostic{
+		{CRD: "CronTab.stable.example.com", Version: "v1", Path: ".spec.-", Reason: "property name has no letters or digits, so the property is dropped"},
+		{CRD: "CronTab.stable.example.com", Version: "v1", Path: ".spec.config", Reason: "schema has no type, so it is typed as any"},
+		{CRD: "CronTab.stable.example.com", Version: "v1", Path: ".spec.labels.*", Reason: "schema has no type, so it is typed as any"},
+		{CRD: "CronTab.stable.example.com", Version: "v1", Path: ".spec.renamed", Reason: "property is dropped"},
+		{CRD: "CronTab.stable.example.com", Version: "v1", Path: ".spec.schedule", Reason: "oneOf branch 1 has no representable type, so the union is typed as any"},
+		{CRD: "CronTab.stable.example.com", Version: "v1", Path: ".spec.volumes[*].file", Reason: `unsupported type "file", so it is typed as any`},
+		{CRD: "CronTab.stable.example.com", Version: "v1beta1", Path: ".spec.args[*]", Reason: "no schema, so it is typed as any"},
+	}
+	if !reflect.DeepEqual(want, diagnostics) {
+		t.Errorf("expected diagnostics:\n%v\ngot:\n%v", want, diagnostics)
+	}
+
+	// Nested read-only properties are reported along with them, sorted by path.
+	pg := &PackageGenerator{
+		CustomResourceGenerators: []CustomResourceGenerator{{
+			CustomResourceDefinition: crd,
+			nestedReadOnly:           map[string][]string{"v1": {".spec.image"}},
+		}},
+		packageSpec: pkgSpec,
+	}
+	diagnostics, err := pg.Diagnostics()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diagnostics) != len(want)+1 || diagnostics[2].Path != ".spec.image" {
+		t.Errorf("expected .spec.image to be reported third, got:\n%v", diagnostics)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ChangeKind describes how a generated file differs from the file on disk.
+type ChangeKind string
+
+const (
+	// FileAdded is a generated file that doesn't exist on disk yet.
+	FileAdded ChangeKind = "added"
+	// FileChanged is a generated file whose contents differ from the file on disk.
+	FileChanged ChangeKind = "changed"
+	// FileRemoved is a previously generated file that is no longer generated, and would be pruned.
+	FileRemoved ChangeKind = "removed"
+)
+
+// FileChange is a difference between the generated code and an output directory.
+type FileChange struct {
+	// Path is the path of the file, including its output directory
+	Path string
+	// Kind is how the file differs
+	Kind ChangeKind
+	// Diff is a unified diff from the file on disk to the generated file
+	Diff string
+}
+
+// DiffPackages generates the code for every language in `settings` like GeneratePackages, but instead of writing it
+// to disk, returns how it differs from the existing output directories, sorted by path. Files are only reported as
+// removed for languages with `Prune` set. Nothing is written, so the output directories may exist even if the settings
+// don't allow overwriting them.
+func DiffPackages(pg *PackageGenerator, settings []*CodegenSettings) ([]FileChange, error) {
+	if err := checkSettings(settings, false); err != nil {
+		return nil, err
+	}
+	if _, err := pg.PackageSpec(); err != nil {
+		return nil, fmt.Errorf("could not generate Pulumi package spec: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	changes := make([][]FileChange, len(settings))
+	errs := make([]error, len(settings))
+	for i, cs := range settings {
+		wg.Go(func() {
+			output, stale, err := generateFiles(pg.forVersion(cs.PackageVersion), cs)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			changes[i], errs[i] = diffFiles(output, cs.Path(), stale)
+		})
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	var all []FileChange
+	for _, c := range changes {
+		all = append(all, c...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Path < all[j].Path
+	})
+	return all, nil
+}
+
+// diffFiles returns how `files` differ from the contents of `outputDir`. The `stale` files, which would be pruned, are
+// reported as removed.
+func diffFiles(files map[string]*bytes.Buffer, outputDir string, stale []string) ([]FileChange, error) {
+	var changes []FileChange
+	for path, code := range files {
+		outputFilePath := filepath.Join(outputDir, path)
+		existing, err := os.ReadFile(outputFilePath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, FileChange{
+				Path: outputFilePath,
+				Kind: FileAdded,
+				Diff: unifiedDiff(outputFilePath, nil, code.Bytes()),
+			})
+		case err != nil:
+			return nil, fmt.Errorf("could not read file %s: %w", outputFilePath, err)
+		case !bytes.Equal(existing, code.Bytes()):
+			changes = append(changes, FileChange{
+				Path: outputFilePath,
+				Kind: FileChanged,
+				Diff: unifiedDiff(outputFilePath, existing, code.Bytes()),
+			})
+		}
+	}
+	for _, path := range stale {
+		outputFilePath := filepath.Join(outputDir, filepath.FromSlash(path))
+		existing, err := os.ReadFile(outputFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file %s: %w", outputFilePath, err)
+		}
+		changes = append(changes, FileChange{
+			Path: outputFilePath,
+			Kind: FileRemoved,
+			Diff: unifiedDiff(outputFilePath, existing, nil),
+		})
+	}
+	return changes, nil
+}
+
+// unifiedDiff returns a unified diff of the file at `path` from `before` to `after`. A nil `before` or `after` means
+// the file doesn't exist.
+func unifiedDiff(path string, before, after []byte) string {
+	fromFile, toFile := "a/"+filepath.ToSlash(path), "b/"+filepath.ToSlash(path)
+	if before == nil {
+		fromFile = "/dev/null"
+	}
+	if after == nil {
+		toFile = "/dev/null"
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	return diff
+}
+
+// splitLines splits `data` into lines, keeping their line endings.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	lines := difflib.SplitLines(string(data))
+	// SplitLines always appends a final line, which is empty if the data ends with a newline.
+	if last := len(lines) - 1; lines[last] == "\n" && bytes.HasSuffix(data, []byte("\n")) {
+		lines = lines[:last]
+	}
+	return lines
+}
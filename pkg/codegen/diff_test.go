@@ -0,0 +1,131 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestDiffFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	writeTestFiles(t, outputDir, map[string]string{
+		"unchanged.txt":   "same\n",
+		"changed.txt":     "one\ntwo\n",
+		"stale/stale.txt": "old\n",
+		"handwritten.txt": "mine\n",
+		ManifestFileName:  testManifest(t, map[string]string{"stale/stale.txt": "old\n"}),
+	})
+	files := map[string]*bytes.Buffer{
+		"unchanged.txt": bytes.NewBufferString("same\n"),
+		"changed.txt":   bytes.NewBufferString("one\nthree\n"),
+		"new/added.txt": bytes.NewBufferString("new\n"),
+	}
+
+	tests := []struct {
+		name  string
+		stale []string
+		want  []FileChange
+	}{
+		{
+			name: "without prune",
+			want: []FileChange{
+				{Path: filepath.Join(outputDir, "changed.txt"), Kind: FileChanged},
+				{Path: filepath.Join(outputDir, "new/added.txt"), Kind: FileAdded},
+			},
+		},
+		{
+			name:  "with prune",
+			stale: []string{"stale/stale.txt"},
+			want: []FileChange{
+				{Path: filepath.Join(outputDir, "changed.txt"), Kind: FileChanged},
+				{Path: filepath.Join(outputDir, "new/added.txt"), Kind: FileAdded},
+				{Path: filepath.Join(outputDir, "stale/stale.txt"), Kind: FileRemoved},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := diffFiles(files, outputDir, tt.stale)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sort.Slice(changes, func(i, j int) bool {
+				return changes[i].Path < changes[j].Path
+			})
+			if len(changes) != len(tt.want) {
+				t.Fatalf("expected %d changes, got %v", len(tt.want), changes)
+			}
+			for i, change := range changes {
+				if change.Path != tt.want[i].Path || change.Kind != tt.want[i].Kind {
+					t.Errorf("expected %s %s, got %s %s", tt.want[i].Kind, tt.want[i].Path, change.Kind, change.Path)
+				}
+			}
+
+			changedPath := filepath.ToSlash(filepath.Join(outputDir, "changed.txt"))
+			wantDiff := "--- a/" + changedPath + "\n+++ b/" + changedPath + "\n@@ -1,2 +1,2 @@\n one\n-two\n+three\n"
+			if changes[0].Diff != wantDiff {
+				t.Errorf("expected diff:\n%s\ngot:\n%s", wantDiff, changes[0].Diff)
+			}
+		})
+	}
+}
+
+func TestDiffFilesMissingOutputDir(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "missing")
+	changes, err := diffFiles(map[string]*bytes.Buffer{
+		"added.txt": bytes.NewBufferString("new\n"),
+	}, outputDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Kind != FileAdded {
+		t.Errorf("expected a single added file, got %v", changes)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after []byte
+		want          string
+	}{
+		{
+			name:  "added",
+			after: []byte("a\nb\n"),
+			want:  "--- /dev/null\n+++ b/x.txt\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:   "removed",
+			before: []byte("a\n"),
+			want:   "--- a/x.txt\n+++ /dev/null\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			name:   "unchanged",
+			before: []byte("a\n"),
+			after:  []byte("a\n"),
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unifiedDiff("x.txt", tt.before, tt.after); got != tt.want {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+		})
+	}
+}
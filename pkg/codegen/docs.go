@@ -0,0 +1,515 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+const (
+	DocsFormatMarkdown string = "markdown"
+	DocsFormatHTML     string = "html"
+)
+
+// objectMetaDocsURL is where the ObjectMeta type, which every resource's metadata uses, is documented.
+const objectMetaDocsURL = "https://kubernetes.io/docs/reference/kubernetes-api/common-definitions/object-meta/"
+
+// docsIndex is the index page of the reference docs, linking to every resource.
+type docsIndex struct {
+	Title   string
+	Version string
+	Groups  []docsGroup
+}
+
+type docsGroup struct {
+	Group    string
+	Versions []docsVersion
+}
+
+type docsVersion struct {
+	Version string
+	Kinds   []docsKind
+}
+
+type docsKind struct {
+	Kind       string
+	Link       string
+	Deprecated bool
+}
+
+// docsPage is the reference page of a single resource, documenting its properties and every type nested in them.
+type docsPage struct {
+	path        string
+	token       string
+	Title       string
+	Index       string
+	Kind        string
+	APIVersion  string
+	Module      string
+	Description string
+	Deprecation string
+	Inputs      []docsProperty
+	Outputs     []docsProperty
+	Types       []docsType
+}
+
+type docsProperty struct {
+	Name        string
+	Type        docsTypeRef
+	Required    bool
+	Default     string
+	Description string
+	Deprecation string
+}
+
+type docsType struct {
+	Name        string
+	Anchor      string
+	Description string
+	Properties  []docsProperty
+	Enum        []docsEnumValue
+}
+
+type docsEnumValue struct {
+	Name        string
+	Value       string
+	Description string
+}
+
+// docsTypeRef is the type of a property, e.g. "list of" CronTabSpec, linking to the type's documentation if it has
+// any.
+type docsTypeRef struct {
+	Prefix string
+	Name   string
+	Link   string
+}
+
+// GenerateDocs returns static API reference docs for the generated resources, in Markdown or HTML depending on
+// cs.DocsFormat. There is an index page linking to a page for every resource, at <module>/<version>/<Kind>.md,
+// documenting its properties and the types nested in them. Type names link to their documentation, so nested types
+// can be browsed.
+func GenerateDocs(pg *PackageGenerator, cs *CodegenSettings) (map[string]*bytes.Buffer, error) {
+	var ext string
+	var render func(name string, data any) ([]byte, error)
+	switch cs.DocsFormat {
+	case "", DocsFormatMarkdown:
+		ext, render = ".md", renderMarkdownDocs
+	case DocsFormatHTML:
+		ext, render = ".html", renderHTMLDocs
+	default:
+		return nil, fmt.Errorf("unsupported docs format %q, must be %q or %q", cs.DocsFormat, DocsFormatMarkdown, DocsFormatHTML)
+	}
+
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Pulumi package spec: %w", err)
+	}
+	index, pages, err := pg.docsPages(pkgSpec, cs.PackageName, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]*bytes.Buffer{}
+	for _, page := range pages {
+		data, err := render("page", page)
+		if err != nil {
+			return nil, fmt.Errorf("could not render docs for %s: %w", page.Kind, err)
+		}
+		files[page.path] = bytes.NewBuffer(data)
+	}
+	data, err := render("index", index)
+	if err != nil {
+		return nil, fmt.Errorf("could not render docs index: %w", err)
+	}
+	files["index"+ext] = bytes.NewBuffer(data)
+	return files, nil
+}
+
+// docsPages returns the index and resource pages of the reference docs for `pkgSpec`. Page paths and links end in
+// `ext`.
+func (pg *PackageGenerator) docsPages(pkgSpec *pschema.PackageSpec, title, ext string) (docsIndex, []*docsPage, error) {
+	index := docsIndex{Title: title, Version: pg.Version}
+
+	crgs := slices.Clone(pg.CustomResourceGenerators)
+	sort.SliceStable(crgs, func(i, j int) bool {
+		if crgs[i].Group != crgs[j].Group {
+			return crgs[i].Group < crgs[j].Group
+		}
+		return crgs[i].Kind < crgs[j].Kind
+	})
+
+	// typePages maps every documented type to the page documenting it, which is the first page that uses it.
+	typePages := map[string]*docsPage{}
+	var pages []*docsPage
+	for _, crg := range crgs {
+		module, err := pg.module(crg.Group)
+		if err != nil {
+			return docsIndex{}, nil, err
+		}
+		for _, version := range crg.Versions {
+			token := getToken(crg.Group, version, crg.Kind)
+			resource, ok := pkgSpec.Resources[token]
+			if !ok {
+				continue
+			}
+			page := &docsPage{
+				path:        module + "/" + version + "/" + crg.Kind + ext,
+				token:       token,
+				Title:       title,
+				Index:       "../../index" + ext,
+				Kind:        crg.Kind,
+				APIVersion:  crg.Group + "/" + version,
+				Module:      module + "/" + version,
+				Description: resource.Description,
+				Deprecation: resource.DeprecationMessage,
+			}
+			pages = append(pages, page)
+			for _, t := range docsTypeTokens(pkgSpec, getToken(crg.Group, version, ""), resource) {
+				if _, ok := typePages[t]; !ok {
+					typePages[t] = page
+				}
+			}
+			index.addKind(crg.Group, version, docsKind{
+				Kind:       crg.Kind,
+				Link:       page.path,
+				Deprecated: resource.DeprecationMessage != "",
+			})
+		}
+	}
+
+	for _, page := range pages {
+		resource := pkgSpec.Resources[page.token]
+		link := func(typeToken string) string {
+			if typeToken == objectMetaToken {
+				return objectMetaDocsURL
+			}
+			owner, ok := typePages[typeToken]
+			if !ok {
+				return ""
+			}
+			anchor := "#" + docsAnchor(typeToken)
+			if owner == page {
+				return anchor
+			}
+			return "../../" + owner.path + anchor
+		}
+
+		page.Inputs = docsProperties(resource.InputProperties, resource.RequiredInputs, link)
+		for _, property := range docsProperties(resource.Properties, resource.Required, link) {
+			if _, ok := resource.InputProperties[property.Name]; !ok {
+				page.Outputs = append(page.Outputs, property)
+			}
+		}
+		for _, t := range slices.Sorted(maps.Keys(typePages)) {
+			if typePages[t] != page {
+				continue
+			}
+			typ := pkgSpec.Types[t]
+			doc := docsType{
+				Name:        docsTypeName(t),
+				Anchor:      docsAnchor(t),
+				Description: typ.Description,
+				Properties:  docsProperties(typ.Properties, typ.Required, link),
+			}
+			for _, value := range typ.Enum {
+				data, _ := json.Marshal(value.Value)
+				doc.Enum = append(doc.Enum, docsEnumValue{Name: value.Name, Value: string(data), Description: value.Description})
+			}
+			page.Types = append(page.Types, doc)
+		}
+	}
+	return index, pages, nil
+}
+
+// addKind adds a link to a resource page to the index, under its group and version.
+func (index *docsIndex) addKind(group, version string, kind docsKind) {
+	if n := len(index.Groups); n == 0 || index.Groups[n-1].Group != group {
+		index.Groups = append(index.Groups, docsGroup{Group: group})
+	}
+	g := &index.Groups[len(index.Groups)-1]
+	i := slices.IndexFunc(g.Versions, func(v docsVersion) bool { return v.Version == version })
+	if i < 0 {
+		g.Versions = append(g.Versions, docsVersion{Version: version})
+		i = len(g.Versions) - 1
+	}
+	g.Versions[i].Kinds = append(g.Versions[i].Kinds, kind)
+	sort.Slice(g.Versions, func(i, j int) bool { return g.Versions[i].Version < g.Versions[j].Version })
+}
+
+// docsTypeTokens returns the tokens of the types in `scope` used by the properties of `resource`, directly or nested
+// in other types, in the order they are first used.
+func docsTypeTokens(pkgSpec *pschema.PackageSpec, scope string, resource pschema.ResourceSpec) []string {
+	var tokens []string
+	seen := map[string]bool{}
+	var visit func(typeSpec pschema.TypeSpec)
+	visitProperties := func(properties map[string]pschema.PropertySpec) {
+		for _, name := range slices.Sorted(maps.Keys(properties)) {
+			visit(properties[name].TypeSpec)
+		}
+	}
+	visit = func(typeSpec pschema.TypeSpec) {
+		switch {
+		case typeSpec.Items != nil:
+			visit(*typeSpec.Items)
+		case typeSpec.AdditionalProperties != nil:
+			visit(*typeSpec.AdditionalProperties)
+		case len(typeSpec.OneOf) > 0:
+			for _, t := range typeSpec.OneOf {
+				visit(t)
+			}
+		case strings.HasPrefix(typeSpec.Ref, typeRefPrefix+scope):
+			token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+			typ, ok := pkgSpec.Types[token]
+			if !ok || seen[token] {
+				return
+			}
+			seen[token] = true
+			tokens = append(tokens, token)
+			visitProperties(typ.Properties)
+		}
+	}
+	visitProperties(resource.InputProperties)
+	visitProperties(resource.Properties)
+	return tokens
+}
+
+// docsProperties returns the documentation of `properties`, sorted by name.
+func docsProperties(properties map[string]pschema.PropertySpec, required []string, link func(string) string) []docsProperty {
+	docs := make([]docsProperty, 0, len(properties))
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		property := properties[name]
+		doc := docsProperty{
+			Name:        name,
+			Type:        docsTypeRefOf(property.TypeSpec, link),
+			Required:    slices.Contains(required, name),
+			Description: property.Description,
+			Deprecation: property.DeprecationMessage,
+		}
+		if property.Default != nil {
+			data, _ := json.Marshal(property.Default)
+			doc.Default = string(data)
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// docsTypeRefOf returns the documented type of `typeSpec`, linking to its documentation with `link`.
+func docsTypeRefOf(typeSpec pschema.TypeSpec, link func(string) string) docsTypeRef {
+	switch {
+	case typeSpec.Items != nil:
+		ref := docsTypeRefOf(*typeSpec.Items, link)
+		ref.Prefix = "list of " + ref.Prefix
+		return ref
+	case typeSpec.AdditionalProperties != nil:
+		ref := docsTypeRefOf(*typeSpec.AdditionalProperties, link)
+		ref.Prefix = "map of " + ref.Prefix
+		return ref
+	case len(typeSpec.OneOf) > 0:
+		names := make([]string, 0, len(typeSpec.OneOf))
+		for _, t := range typeSpec.OneOf {
+			ref := docsTypeRefOf(t, link)
+			names = append(names, ref.Prefix+ref.Name)
+		}
+		return docsTypeRef{Name: strings.Join(names, " or ")}
+	case typeSpec.Ref == anyTypeRef:
+		return docsTypeRef{Name: "any"}
+	case strings.HasPrefix(typeSpec.Ref, typeRefPrefix):
+		token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+		return docsTypeRef{Name: docsTypeName(token), Link: link(token)}
+	}
+	return docsTypeRef{Name: typeSpec.Type}
+}
+
+// docsTypeName returns the name of the type with the given token, e.g. CronTabSpec for
+// kubernetes:stable.example.com/v1:CronTabSpec.
+func docsTypeName(token string) string {
+	return token[strings.LastIndex(token, ":")+1:]
+}
+
+// docsAnchor returns the anchor of the type with the given token, which matches the anchor Markdown renderers
+// generate for its heading.
+func docsAnchor(token string) string {
+	return strings.ToLower(docsTypeName(token))
+}
+
+var markdownDocsTemplate = texttemplate.Must(texttemplate.New("docs").Funcs(texttemplate.FuncMap{
+	"cell": markdownCell,
+	"type": func(ref docsTypeRef) string {
+		if ref.Link == "" {
+			return ref.Prefix + ref.Name
+		}
+		return ref.Prefix + "[" + ref.Name + "](" + ref.Link + ")"
+	},
+}).Parse(`
+{{- define "properties" -}}
+| Property | Type | Required | Default | Description |
+| --- | --- | --- | --- | --- |
+{{range .}}| ` + "`{{.Name}}`" + ` | {{type .Type}} | {{if .Required}}yes{{else}}no{{end}} | {{with .Default}}` + "`{{cell .}}`" + `{{end}} | {{if .Deprecation}}**Deprecated:** {{cell .Deprecation}}<br>{{end}}{{cell .Description}} |
+{{end -}}
+{{end -}}
+
+{{define "index" -}}
+# {{.Title}} API reference
+{{with .Version}}
+Version {{.}}
+{{end}}
+{{- range .Groups}}
+## {{.Group}}
+{{range .Versions}}
+### {{.Version}}
+
+{{range .Kinds}}- [{{.Kind}}]({{.Link}}){{if .Deprecated}} (deprecated){{end}}
+{{end}}{{end}}{{end}}{{end -}}
+
+{{define "page" -}}
+# {{.Kind}}
+
+` + "`{{.APIVersion}}`" + ` in module ` + "`{{.Module}}`" + ` of the [{{.Title}} API reference]({{.Index}}).
+{{with .Deprecation}}
+> **Deprecated:** {{.}}
+{{end}}{{with .Description}}
+{{.}}
+{{end}}
+## Inputs
+
+{{template "properties" .Inputs}}
+{{- with .Outputs}}
+## Outputs
+
+{{template "properties" .}}
+{{- end}}
+{{- with .Types}}
+## Types
+{{range .}}
+### {{.Name}}
+{{with .Description}}
+{{.}}
+{{end}}
+{{- with .Properties}}
+{{template "properties" .}}
+{{- end}}
+{{- with .Enum}}
+| Name | Value | Description |
+| --- | --- | --- |
+{{range .}}| ` + "`{{.Name}}`" + ` | ` + "`{{cell .Value}}`" + ` | {{cell .Description}} |
+{{end -}}
+{{end -}}
+{{end -}}
+{{end -}}
+{{end}}`))
+
+// markdownCell escapes `s` for use in a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "|", "\\|")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+func renderMarkdownDocs(name string, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := markdownDocsTemplate.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var htmlDocsTemplate = htmltemplate.Must(htmltemplate.New("docs").Parse(`
+{{- define "head" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.}}</title>
+<style>
+body { font-family: sans-serif; max-width: 72em; margin: 2em auto; padding: 0 1em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+.description { white-space: pre-wrap; }
+.deprecated { color: #a33; }
+</style>
+</head>
+<body>
+{{end -}}
+
+{{define "type" -}}
+{{.Prefix}}{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}
+{{- end -}}
+
+{{define "properties" -}}
+<table>
+<tr><th>Property</th><th>Type</th><th>Required</th><th>Default</th><th>Description</th></tr>
+{{range .}}<tr><td><code>{{.Name}}</code></td><td>{{template "type" .Type}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td><td>{{with .Default}}<code>{{.}}</code>{{end}}</td><td>{{with .Deprecation}}<p class="deprecated">Deprecated: {{.}}</p>{{end}}<div class="description">{{.Description}}</div></td></tr>
+{{end -}}
+</table>
+{{end -}}
+
+{{define "index" -}}
+{{template "head" (print .Title " API reference")}}<h1>{{.Title}} API reference</h1>
+{{with .Version}}<p>Version {{.}}</p>
+{{end}}
+{{- range .Groups}}<h2>{{.Group}}</h2>
+{{range .Versions}}<h3>{{.Version}}</h3>
+<ul>
+{{range .Kinds}}<li><a href="{{.Link}}">{{.Kind}}</a>{{if .Deprecated}} (deprecated){{end}}</li>
+{{end}}</ul>
+{{end}}{{end -}}
+</body>
+</html>
+{{end -}}
+
+{{define "page" -}}
+{{template "head" (print .Kind " - " .Title " API reference")}}<h1>{{.Kind}}</h1>
+<p><code>{{.APIVersion}}</code> in module <code>{{.Module}}</code> of the <a href="{{.Index}}">{{.Title}} API reference</a>.</p>
+{{with .Deprecation}}<p class="deprecated">Deprecated: {{.}}</p>
+{{end}}{{with .Description}}<div class="description">{{.}}</div>
+{{end}}<h2>Inputs</h2>
+{{template "properties" .Inputs}}
+{{- with .Outputs}}<h2>Outputs</h2>
+{{template "properties" .}}
+{{- end}}
+{{- with .Types}}<h2>Types</h2>
+{{range .}}<h3 id="{{.Anchor}}">{{.Name}}</h3>
+{{with .Description}}<div class="description">{{.}}</div>
+{{end}}
+{{- with .Properties}}{{template "properties" .}}{{end}}
+{{- with .Enum}}<table>
+<tr><th>Name</th><th>Value</th><th>Description</th></tr>
+{{range .}}<tr><td><code>{{.Name}}</code></td><td><code>{{.Value}}</code></td><td class="description">{{.Description}}</td></tr>
+{{end -}}
+</table>
+{{end -}}
+{{end -}}
+{{end -}}
+</body>
+</html>
+{{end}}`))
+
+func renderHTMLDocs(name string, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlDocsTemplate.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
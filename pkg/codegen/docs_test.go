@@ -0,0 +1,220 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func newCronTabTestPackageGenerator() *PackageGenerator {
+	specType := pschema.TypeSpec{Type: Object, Ref: "#/types/kubernetes:stable.example.com/v1:CronTabSpec"}
+	return &PackageGenerator{
+		Version: "1.2.3",
+		CustomResourceGenerators: []CustomResourceGenerator{
+			{Group: "stable.example.com", Kind: "CronTab", Versions: []string{"v1"}},
+			{Group: "stable.example.com", Kind: "Schedule", Versions: []string{"v1"}},
+		},
+		packageSpec: &pschema.PackageSpec{
+			Name: pulumiKubernetesNameShim,
+			Resources: map[string]pschema.ResourceSpec{
+				"kubernetes:stable.example.com/v1:CronTab": {
+					ObjectTypeSpec: pschema.ObjectTypeSpec{
+						Description: "A CronTab runs a command on a schedule.",
+						Properties: map[string]pschema.PropertySpec{
+							"metadata": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: "#/types/" + objectMetaToken}},
+							"spec":     {TypeSpec: specType},
+							"status":   {TypeSpec: pschema.TypeSpec{Ref: anyTypeRef}, Description: "Observed state."},
+						},
+					},
+					InputProperties: map[string]pschema.PropertySpec{
+						"metadata": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: "#/types/" + objectMetaToken}},
+						"spec":     {TypeSpec: specType, Description: "Desired state."},
+					},
+					RequiredInputs: []string{"spec"},
+				},
+				"kubernetes:stable.example.com/v1:Schedule": {
+					DeprecationMessage: "use CronTab",
+					InputProperties: map[string]pschema.PropertySpec{
+						"crontab": {TypeSpec: specType},
+					},
+				},
+			},
+			Types: map[string]pschema.ComplexTypeSpec{
+				"kubernetes:stable.example.com/v1:CronTabSpec": {
+					ObjectTypeSpec: pschema.ObjectTypeSpec{
+						Type:        Object,
+						Description: "The schedule | and command.",
+						Properties: map[string]pschema.PropertySpec{
+							"cronSpec": {TypeSpec: pschema.TypeSpec{Type: String}, Description: "Cron schedule,\ne.g. */5 * * * *"},
+							"replicas": {TypeSpec: pschema.TypeSpec{Type: Integer}, Default: 1},
+							"policy":   {TypeSpec: pschema.TypeSpec{Type: String, Ref: "#/types/kubernetes:stable.example.com/v1:CronTabSpecPolicy"}},
+							"args": {TypeSpec: pschema.TypeSpec{Type: Array, Items: &pschema.TypeSpec{
+								Type: Object, Ref: "#/types/kubernetes:stable.example.com/v1:CronTabSpecArgs",
+							}}},
+						},
+						Required: []string{"cronSpec"},
+					},
+				},
+				"kubernetes:stable.example.com/v1:CronTabSpecArgs": {
+					ObjectTypeSpec: pschema.ObjectTypeSpec{
+						Type:       Object,
+						Properties: map[string]pschema.PropertySpec{"value": {TypeSpec: intOrStringTypeSpec}},
+					},
+				},
+				"kubernetes:stable.example.com/v1:CronTabSpecPolicy": {
+					ObjectTypeSpec: pschema.ObjectTypeSpec{Type: String},
+					Enum: []pschema.EnumValueSpec{
+						{Name: "Allow", Value: "Allow", Description: "Runs concurrently."},
+						{Name: "Forbid", Value: "Forbid"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateDocsMarkdown(t *testing.T) {
+	files, err := GenerateDocs(newCronTabTestPackageGenerator(), &CodegenSettings{Language: Docs, PackageName: "crds"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	if want := []string{"index.md", "stable/v1/CronTab.md", "stable/v1/Schedule.md"}; !slices.Equal(want, paths) {
+		t.Fatalf("expected files %v, got %v", want, paths)
+	}
+
+	wantIndex := `# crds API reference
+
+Version 1.2.3
+
+## stable.example.com
+
+### v1
+
+- [CronTab](stable/v1/CronTab.md)
+- [Schedule](stable/v1/Schedule.md) (deprecated)
+`
+	if got := files["index.md"].String(); got != wantIndex {
+		t.Errorf("expected index:\n%s\ngot:\n%s", wantIndex, got)
+	}
+
+	wantCronTab := `# CronTab
+
+` + "`stable.example.com/v1`" + ` in module ` + "`stable/v1`" + ` of the [crds API reference](../../index.md).
+
+A CronTab runs a command on a schedule.
+
+## Inputs
+
+| Property | Type | Required | Default | Description |
+| --- | --- | --- | --- | --- |
+| ` + "`metadata`" + ` | [ObjectMeta](` + objectMetaDocsURL + `) | no |  |  |
+| ` + "`spec`" + ` | [CronTabSpec](#crontabspec) | yes |  | Desired state. |
+
+## Outputs
+
+| Property | Type | Required | Default | Description |
+| --- | --- | --- | --- | --- |
+| ` + "`status`" + ` | any | no |  | Observed state. |
+
+## Types
+
+### CronTabSpec
+
+The schedule | and command.
+
+| Property | Type | Required | Default | Description |
+| --- | --- | --- | --- | --- |
+| ` + "`args`" + ` | list of [CronTabSpecArgs](#crontabspecargs) | no |  |  |
+| ` + "`cronSpec`" + ` | string | yes |  | Cron schedule,<br>e.g. */5 * * * * |
+| ` + "`policy`" + ` | [CronTabSpecPolicy](#crontabspecpolicy) | no |  |  |
+| ` + "`replicas`" + ` | integer | no | ` + "`1`" + ` |  |
+
+### CronTabSpecArgs
+
+| Property | Type | Required | Default | Description |
+| --- | --- | --- | --- | --- |
+| ` + "`value`" + ` | integer or string | no |  |  |
+
+### CronTabSpecPolicy
+
+| Name | Value | Description |
+| --- | --- | --- |
+| ` + "`Allow`" + ` | ` + "`\"Allow\"`" + ` | Runs concurrently. |
+| ` + "`Forbid`" + ` | ` + "`\"Forbid\"`" + ` |  |
+`
+	if got := files["stable/v1/CronTab.md"].String(); got != wantCronTab {
+		t.Errorf("expected CronTab page:\n%s\ngot:\n%s", wantCronTab, got)
+	}
+
+	// Types documented on another page are linked there.
+	schedule := files["stable/v1/Schedule.md"].String()
+	for _, want := range []string{
+		"> **Deprecated:** use CronTab",
+		"[CronTabSpec](../../stable/v1/CronTab.md#crontabspec)",
+	} {
+		if !strings.Contains(schedule, want) {
+			t.Errorf("expected Schedule page to contain %q, got:\n%s", want, schedule)
+		}
+	}
+	if strings.Contains(schedule, "## Types") {
+		t.Errorf("expected Schedule page to have no types, got:\n%s", schedule)
+	}
+}
+
+func TestGenerateDocsHTML(t *testing.T) {
+	files, err := GenerateDocs(newCronTabTestPackageGenerator(), &CodegenSettings{
+		Language: Docs, PackageName: "crds", DocsFormat: DocsFormatHTML,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if files["index.html"] == nil || files["stable/v1/CronTab.html"] == nil {
+		t.Fatalf("expected index.html and stable/v1/CronTab.html, got %v", files)
+	}
+
+	page := files["stable/v1/CronTab.html"].String()
+	for _, want := range []string{
+		`<h3 id="crontabspec">CronTabSpec</h3>`,
+		`<a href="#crontabspec">CronTabSpec</a>`,
+		`list of <a href="#crontabspecargs">CronTabSpecArgs</a>`,
+		`<a href="../../index.html">crds API reference</a>`,
+		`<div class="description">The schedule | and command.</div>`,
+		`<code>&#34;Allow&#34;</code>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected CronTab page to contain %q, got:\n%s", want, page)
+		}
+	}
+	if index := files["index.html"].String(); !strings.Contains(index, `<a href="stable/v1/Schedule.html">Schedule</a> (deprecated)`) {
+		t.Errorf("expected index to link to Schedule, got:\n%s", index)
+	}
+}
+
+func TestGenerateDocsInvalidFormat(t *testing.T) {
+	_, err := GenerateDocs(newCronTabTestPackageGenerator(), &CodegenSettings{Language: Docs, DocsFormat: "pdf"})
+	if err == nil || !strings.Contains(err.Error(), `unsupported docs format "pdf"`) {
+		t.Errorf("expected an unsupported format error, got %v", err)
+	}
+}
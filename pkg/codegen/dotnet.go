@@ -18,10 +18,7 @@ import (
 	"bytes"
 	"fmt"
 
-	"github.com/pulumi/crd2pulumi/internal/versions"
 	"github.com/pulumi/pulumi-dotnet/pulumi-language-dotnet/v3/codegen"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 var unneededDotNetFiles = []string{
@@ -35,20 +32,8 @@ var unneededDotNetFiles = []string{
 func GenerateDotNet(pg *PackageGenerator, cs *CodegenSettings) (map[string]*bytes.Buffer, error) {
 	pkg := pg.SchemaPackageWithObjectMetaType()
 
-	// Set up C# namespaces
-	namespaces := map[string]string{}
-	for _, groupVersion := range pg.GroupVersions {
-		group, version, err := versions.SplitGroupVersion(groupVersion)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version: %w", err)
-		}
-		groupPrefix, err := versions.GroupPrefix(group)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version: %w", err)
-		}
-		namespaces[groupVersion] = cases.Title(language.Und).String(groupPrefix) + "." + versions.VersionToUpper(version)
-	}
-	namespaces["meta/v1"] = "Meta.V1"
+	// C# namespaces are set in the package spec by setLanguageModules, so
+	// they follow the same group modules as the other languages.
 
 	// Configure C# language-specific settings. Notice that we set
 	// `compatibility` to `kubernetes20`. This is because the actual ObjectMeta
@@ -81,6 +66,12 @@ func GenerateDotNet(pg *PackageGenerator, cs *CodegenSettings) (map[string]*byte
 		delete(files, unneededFile)
 	}
 
+	if pg.lookupFunctions {
+		if err := writeLookupFunctions(pg, DotNet, files); err != nil {
+			return nil, err
+		}
+	}
+
 	buffers := map[string]*bytes.Buffer{}
 	for name, code := range files {
 		buffers[name] = bytes.NewBuffer(code)
@@ -0,0 +1,169 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"slices"
+	"strings"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// addEmbeddedResources types the properties of the resources generated from
+// `crd` that are marked x-kubernetes-embedded-resource as Kubernetes objects
+// with an apiVersion, kind and ObjectMeta metadata, like the resources
+// themselves. The OpenAPI builder leaves these out of embedded resources that
+// preserve unknown fields, which are then typed as arbitrary JSON.
+func addEmbeddedResources(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) {
+	for _, v := range crd.Spec.Versions {
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		token := getToken(crd.Spec.Group, v.Name, crd.Spec.Names.Kind)
+		scope := getToken(crd.Spec.Group, v.Name, "")
+		for _, t := range []string{token, token + "Patch"} {
+			resource, ok := pkgSpec.Resources[t]
+			if !ok {
+				continue
+			}
+			patch := strings.HasSuffix(t, "Patch")
+			for _, properties := range []map[string]pschema.PropertySpec{resource.InputProperties, resource.Properties} {
+				for name := range properties {
+					if name != "metadata" {
+						addEmbeddedResourceProperty(pkgSpec, scope, token, patch, properties, name, *v.Schema.OpenAPIV3Schema)
+					}
+				}
+			}
+		}
+	}
+}
+
+// addEmbeddedResourceProperty types the property `name` of `properties`, and
+// the properties of the object types it refers to, as embedded resources if
+// their schemas in `schema` are marked x-kubernetes-embedded-resource. `parent`
+// is the token of the type `properties` belong to, without its Patch suffix.
+func addEmbeddedResourceProperty(
+	pkgSpec *pschema.PackageSpec, scope, parent string, patch bool, properties map[string]pschema.PropertySpec, name string,
+	schema extensionv1.JSONSchemaProps,
+) {
+	propertySchema, ok := schema.Properties[name]
+	if !ok {
+		return
+	}
+	property := properties[name]
+	typeSpec := &property.TypeSpec
+	if propertySchema.Items != nil && propertySchema.Items.Schema != nil {
+		if typeSpec.Items == nil {
+			return
+		}
+		typeSpec, propertySchema = typeSpec.Items, *propertySchema.Items.Schema
+	}
+
+	token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+	if !strings.HasPrefix(token, scope) {
+		if !propertySchema.XEmbeddedResource {
+			return
+		}
+		// The embedded resource was typed as arbitrary JSON, so add a type named like the generated types. Its body
+		// stays free-form: the properties its schema declares are typed as any.
+		token = parent + sanitizeReferenceName(name)
+		if patch {
+			token += "Patch"
+		}
+		// The input and output properties share the type.
+		if _, ok := pkgSpec.Types[token]; !ok {
+			body := map[string]pschema.PropertySpec{}
+			for bodyName, bodySchema := range propertySchema.Properties {
+				body[bodyName] = pschema.PropertySpec{TypeSpec: anyTypeSpec, Description: bodySchema.Description}
+			}
+			pkgSpec.Types[token] = pschema.ComplexTypeSpec{ObjectTypeSpec: pschema.ObjectTypeSpec{
+				Type:        Object,
+				Description: propertySchema.Description,
+				Properties:  body,
+			}}
+		}
+		*typeSpec = pschema.TypeSpec{Type: Object, Ref: typeRefPrefix + token}
+		properties[name] = property
+	}
+
+	typ, ok := pkgSpec.Types[token]
+	if !ok || len(typ.Enum) > 0 {
+		return
+	}
+	if propertySchema.XEmbeddedResource {
+		typ.ObjectTypeSpec = embeddedResourceType(typ.ObjectTypeSpec, patch, propertySchema.XPreserveUnknownFields != nil && *propertySchema.XPreserveUnknownFields)
+		pkgSpec.Types[token] = typ
+	}
+	for propertyName := range typ.Properties {
+		addEmbeddedResourceProperty(pkgSpec, scope, strings.TrimSuffix(token, "Patch"), patch, typ.Properties, propertyName, propertySchema)
+	}
+}
+
+// embeddedResourceType returns `typ` with the apiVersion, kind and ObjectMeta
+// metadata properties of an embedded resource, and a free-form spec if it
+// preserves unknown fields and has none. The properties of Patch types are
+// optional.
+func embeddedResourceType(typ pschema.ObjectTypeSpec, patch, preserveUnknownFields bool) pschema.ObjectTypeSpec {
+	metaRef := objectMetaRef
+	if patch {
+		metaRef = objectMetaPatchRef
+	}
+	embedded := map[string]pschema.PropertySpec{
+		"apiVersion": {
+			TypeSpec:    pschema.TypeSpec{Type: String},
+			Description: "APIVersion defines the versioned schema of this representation of an object.",
+		},
+		"kind": {
+			TypeSpec:    pschema.TypeSpec{Type: String},
+			Description: "Kind is a string value representing the REST resource this object represents.",
+		},
+		"metadata": {
+			TypeSpec:    pschema.TypeSpec{Type: Object, Ref: metaRef},
+			Description: "Standard object's metadata.",
+		},
+	}
+	if _, ok := typ.Properties["spec"]; !ok && preserveUnknownFields {
+		embedded["spec"] = pschema.PropertySpec{
+			TypeSpec:    anyTypeSpec,
+			Description: "The free-form body of the embedded resource.",
+		}
+	}
+
+	properties := make(map[string]pschema.PropertySpec, len(typ.Properties)+len(embedded))
+	for name, property := range embedded {
+		properties[name] = property
+	}
+	for name, property := range typ.Properties {
+		// The API server validates the metadata of embedded resources as ObjectMeta, whatever their schema says.
+		if name == "metadata" {
+			if property.Description != "" {
+				properties[name] = pschema.PropertySpec{TypeSpec: properties[name].TypeSpec, Description: property.Description}
+			}
+			continue
+		}
+		properties[name] = property
+	}
+	typ.Properties = properties
+	if !patch {
+		typ.Required = slices.Clone(typ.Required)
+		for _, name := range []string{"apiVersion", "kind"} {
+			if !slices.Contains(typ.Required, name) {
+				typ.Required = append(typ.Required, name)
+			}
+		}
+	}
+	return typ
+}
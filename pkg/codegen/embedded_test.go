@@ -0,0 +1,202 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"reflect"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestAddEmbeddedResources(t *testing.T) {
+	preserve := true
+	crd := filterTestCRD("argoproj.io", "Rollout", "v1")
+	crd.Spec.Versions[0].Schema = &extensionv1.CustomResourceValidation{OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+		Type: Object,
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"spec": {
+				Type: Object,
+				Properties: map[string]extensionv1.JSONSchemaProps{
+					"workload": {
+						Type:                   Object,
+						Description:            "The workload to roll out.",
+						XEmbeddedResource:      true,
+						XPreserveUnknownFields: &preserve,
+						Properties: map[string]extensionv1.JSONSchemaProps{
+							"data": {Type: Object, Description: "Data of a ConfigMap."},
+						},
+					},
+					"volumeClaims": {
+						Type: Array,
+						Items: &extensionv1.JSONSchemaPropsOrArray{Schema: &extensionv1.JSONSchemaProps{
+							Type:              Object,
+							XEmbeddedResource: true,
+							Properties: map[string]extensionv1.JSONSchemaProps{
+								"metadata": {Type: Object},
+								"spec":     {Type: Object},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}}
+
+	spec := func(token string) map[string]pschema.PropertySpec {
+		return map[string]pschema.PropertySpec{
+			"metadata": {TypeSpec: pschema.TypeSpec{Ref: objectMetaRef}},
+			"spec":     {TypeSpec: pschema.TypeSpec{Type: Object, Ref: typeRefPrefix + token}},
+		}
+	}
+	specType := func(volumeClaims string) pschema.ComplexTypeSpec {
+		return pschema.ComplexTypeSpec{ObjectTypeSpec: pschema.ObjectTypeSpec{Type: Object, Properties: map[string]pschema.PropertySpec{
+			"workload": {TypeSpec: arbitraryJSONTypeSpec},
+			"volumeClaims": {TypeSpec: pschema.TypeSpec{
+				Type:  Array,
+				Items: &pschema.TypeSpec{Type: Object, Ref: typeRefPrefix + volumeClaims},
+			}},
+		}}}
+	}
+	volumeClaimType := func(metadata string) pschema.ComplexTypeSpec {
+		return pschema.ComplexTypeSpec{ObjectTypeSpec: pschema.ObjectTypeSpec{Type: Object, Properties: map[string]pschema.PropertySpec{
+			"metadata": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: typeRefPrefix + metadata}},
+			"spec":     {TypeSpec: arbitraryJSONTypeSpec},
+		}}}
+	}
+	pkgSpec := &pschema.PackageSpec{
+		Resources: map[string]pschema.ResourceSpec{
+			"kubernetes:argoproj.io/v1:Rollout": {
+				ObjectTypeSpec:  pschema.ObjectTypeSpec{Properties: spec("kubernetes:argoproj.io/v1:RolloutSpec")},
+				InputProperties: spec("kubernetes:argoproj.io/v1:RolloutSpec"),
+			},
+			"kubernetes:argoproj.io/v1:RolloutPatch": {
+				ObjectTypeSpec:  pschema.ObjectTypeSpec{Properties: spec("kubernetes:argoproj.io/v1:RolloutSpecPatch")},
+				InputProperties: spec("kubernetes:argoproj.io/v1:RolloutSpecPatch"),
+			},
+		},
+		Types: map[string]pschema.ComplexTypeSpec{
+			"kubernetes:argoproj.io/v1:RolloutSpec":                          specType("kubernetes:argoproj.io/v1:RolloutSpecVolumeClaims"),
+			"kubernetes:argoproj.io/v1:RolloutSpecPatch":                     specType("kubernetes:argoproj.io/v1:RolloutSpecVolumeClaimsPatch"),
+			"kubernetes:argoproj.io/v1:RolloutSpecVolumeClaims":              volumeClaimType("kubernetes:argoproj.io/v1:RolloutSpecVolumeClaimsMetadata"),
+			"kubernetes:argoproj.io/v1:RolloutSpecVolumeClaimsPatch":         volumeClaimType("kubernetes:argoproj.io/v1:RolloutSpecVolumeClaimsMetadataPatch"),
+			"kubernetes:argoproj.io/v1:RolloutSpecVolumeClaimsMetadata":      {ObjectTypeSpec: pschema.ObjectTypeSpec{Type: Object}},
+			"kubernetes:argoproj.io/v1:RolloutSpecVolumeClaimsMetadataPatch": {ObjectTypeSpec: pschema.ObjectTypeSpec{Type: Object}},
+		},
+	}
+	addEmbeddedResources(pkgSpec, crd)
+
+	tests := []struct {
+		token        string
+		wantRef      map[string]string
+		wantRequired []string
+	}{
+		{
+			token: "kubernetes:argoproj.io/v1:RolloutSpecWorkload",
+			wantRef: map[string]string{
+				"apiVersion": "", "kind": "", "metadata": objectMetaRef, "spec": anyTypeRef, "data": anyTypeRef,
+			},
+			wantRequired: []string{"apiVersion", "kind"},
+		},
+		{
+			token: "kubernetes:argoproj.io/v1:RolloutSpecWorkloadPatch",
+			wantRef: map[string]string{
+				"apiVersion": "", "kind": "", "metadata": objectMetaPatchRef, "spec": anyTypeRef, "data": anyTypeRef,
+			},
+		},
+		{
+			token:        "kubernetes:argoproj.io/v1:RolloutSpecVolumeClaims",
+			wantRef:      map[string]string{"apiVersion": "", "kind": "", "metadata": objectMetaRef, "spec": ""},
+			wantRequired: []string{"apiVersion", "kind"},
+		},
+		{
+			token:   "kubernetes:argoproj.io/v1:RolloutSpecVolumeClaimsPatch",
+			wantRef: map[string]string{"apiVersion": "", "kind": "", "metadata": objectMetaPatchRef, "spec": ""},
+		},
+	}
+	for _, tt := range tests {
+		typ, ok := pkgSpec.Types[tt.token]
+		if !ok {
+			t.Errorf("expected a %s type", tt.token)
+			continue
+		}
+		refs := map[string]string{}
+		for name, property := range typ.Properties {
+			refs[name] = property.Ref
+		}
+		if !reflect.DeepEqual(tt.wantRef, refs) {
+			t.Errorf("expected %s to have properties %v, got %v", tt.token, tt.wantRef, refs)
+		}
+		if !reflect.DeepEqual(tt.wantRequired, typ.Required) {
+			t.Errorf("expected %s to require %v, got %v", tt.token, tt.wantRequired, typ.Required)
+		}
+	}
+
+	for _, suffix := range []string{"", "Patch"} {
+		token := "kubernetes:argoproj.io/v1:RolloutSpec" + suffix
+		want := typeRefPrefix + "kubernetes:argoproj.io/v1:RolloutSpecWorkload" + suffix
+		if got := pkgSpec.Types[token].Properties["workload"].Ref; got != want {
+			t.Errorf("expected %s.workload to refer to %s, got %s", token, want, got)
+		}
+	}
+}
+
+func TestAddEmbeddedResourcesTypeNames(t *testing.T) {
+	crd := filterTestCRD("argoproj.io", "Rollout", "v1")
+	crd.Spec.Versions[0].Schema = &extensionv1.CustomResourceValidation{OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+		Type: Object,
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"spec": {
+				Type: Object,
+				Properties: map[string]extensionv1.JSONSchemaProps{
+					"pod-template": {Type: Object, XEmbeddedResource: true},
+					"args":         {Type: Object, XEmbeddedResource: true},
+				},
+			},
+		},
+	}}
+	properties := map[string]pschema.PropertySpec{
+		"spec": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: typeRefPrefix + "kubernetes:argoproj.io/v1:RolloutSpec"}},
+	}
+	pkgSpec := &pschema.PackageSpec{
+		Resources: map[string]pschema.ResourceSpec{
+			"kubernetes:argoproj.io/v1:Rollout": {
+				ObjectTypeSpec:  pschema.ObjectTypeSpec{Properties: properties},
+				InputProperties: properties,
+			},
+		},
+		Types: map[string]pschema.ComplexTypeSpec{
+			"kubernetes:argoproj.io/v1:RolloutSpec": {ObjectTypeSpec: pschema.ObjectTypeSpec{Type: Object, Properties: map[string]pschema.PropertySpec{
+				"pod-template": {TypeSpec: arbitraryJSONTypeSpec},
+				"args":         {TypeSpec: arbitraryJSONTypeSpec},
+			}}},
+		},
+	}
+	addEmbeddedResources(pkgSpec, crd)
+
+	want := map[string]string{
+		"pod-template": "kubernetes:argoproj.io/v1:RolloutSpecPodTemplate",
+		"args":         "kubernetes:argoproj.io/v1:RolloutSpecArguments",
+	}
+	for name, token := range want {
+		if got := pkgSpec.Types["kubernetes:argoproj.io/v1:RolloutSpec"].Properties[name].Ref; got != typeRefPrefix+token {
+			t.Errorf("expected %s to refer to %s, got %s", name, token, got)
+		}
+		if _, ok := pkgSpec.Types[token]; !ok {
+			t.Errorf("expected a %s type", token)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/cgstrings"
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const typeRefPrefix = "#/types/"
+
+var nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// enumValueDescriptionRegex matches the per-value descriptions that Kubernetes
+// generates for enum types, e.g. - `"Always"` means the image is always pulled.
+var enumValueDescriptionRegex = regexp.MustCompile("(?m)^\\s*-\\s*`\"((?:[^\"\\\\]|\\\\.)*)\"`\\s*(.*)$")
+
+// addEnums replaces the properties of the resources generated from `crd` that
+// have an `enum` in the CRD schema with references to Pulumi enum types.
+// crdToOpenAPI strips value validations such as enums before the package spec
+// is generated, so they are read from the CRD's schemas instead.
+func addEnums(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) {
+	for _, v := range crd.Spec.Versions {
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		token := getToken(crd.Spec.Group, v.Name, crd.Spec.Names.Kind)
+		scope := getToken(crd.Spec.Group, v.Name, "")
+		for _, t := range []string{token, token + "Patch"} {
+			resource, ok := pkgSpec.Resources[t]
+			if !ok {
+				continue
+			}
+			addPropertyEnums(pkgSpec, scope, token, resource.InputProperties, *v.Schema.OpenAPIV3Schema)
+			addPropertyEnums(pkgSpec, scope, token, resource.Properties, *v.Schema.OpenAPIV3Schema)
+		}
+	}
+}
+
+// addPropertyEnums adds the enums in the properties of `schema` to the matching
+// `properties` of the type `parent`. Only types whose token starts with `scope`
+// are followed, so shared types such as ObjectMeta are left alone.
+func addPropertyEnums(
+	pkgSpec *pschema.PackageSpec, scope, parent string, properties map[string]pschema.PropertySpec, schema extensionv1.JSONSchemaProps,
+) {
+	for name, property := range properties {
+		propertySchema, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		property.TypeSpec = addTypeEnums(pkgSpec, scope, parent+sanitizeReferenceName(name), property.TypeSpec, propertySchema)
+		properties[name] = property
+	}
+}
+
+// addTypeEnums returns `typeSpec` with the enum in `schema` replaced by a
+// reference to a new enum type named `name`, recursing into arrays, maps and
+// object types.
+func addTypeEnums(
+	pkgSpec *pschema.PackageSpec, scope, name string, typeSpec pschema.TypeSpec, schema extensionv1.JSONSchemaProps,
+) pschema.TypeSpec {
+	switch {
+	case typeSpec.Items != nil:
+		if schema.Items != nil && schema.Items.Schema != nil {
+			items := addTypeEnums(pkgSpec, scope, name, *typeSpec.Items, *schema.Items.Schema)
+			typeSpec.Items = &items
+		}
+	case typeSpec.AdditionalProperties != nil:
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			additionalProperties := addTypeEnums(pkgSpec, scope, name, *typeSpec.AdditionalProperties, *schema.AdditionalProperties.Schema)
+			typeSpec.AdditionalProperties = &additionalProperties
+		}
+	case strings.HasPrefix(typeSpec.Ref, typeRefPrefix+scope):
+		token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+		if typ, ok := pkgSpec.Types[token]; ok && len(typ.Enum) == 0 {
+			// Patch types share the enums of the type they patch.
+			addPropertyEnums(pkgSpec, scope, strings.TrimSuffix(token, "Patch"), typ.Properties, schema)
+		}
+	case typeSpec.Ref == "" && len(schema.Enum) > 0:
+		values := make([]any, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			var v any
+			if err := json.Unmarshal(value.Raw, &v); err != nil {
+				return typeSpec
+			}
+			values = append(values, v)
+		}
+		enum, ok := newEnumType(values, typeSpec.Type, schema.Description)
+		if !ok || enum.Type != typeSpec.Type {
+			return typeSpec
+		}
+		if existing, ok := pkgSpec.Types[name]; ok && len(existing.Enum) == 0 {
+			name += "Enum"
+		}
+		pkgSpec.Types[name] = enum
+		return pschema.TypeSpec{Type: enum.Type, Ref: typeRefPrefix + name}
+	}
+	return typeSpec
+}
+
+// newEnumType returns an enum type with the given values, or false if the
+// values can't be represented by a single Pulumi enum type, e.g. because they
+// mix strings and integers. Whole numbers are integers, unless `schemaType` is
+// number. Per-value descriptions are read from `description`, if it lists them
+// the way Kubernetes does.
+func newEnumType(values []any, schemaType, description string) (pschema.ComplexTypeSpec, bool) {
+	enumType := ""
+	normalized := make([]any, 0, len(values))
+	for _, value := range values {
+		valueType := ""
+		switch v := value.(type) {
+		case string:
+			valueType = String
+		case bool:
+			valueType = Boolean
+		case int64:
+			valueType, value = Integer, v
+		case int:
+			valueType, value = Integer, int64(v)
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+				valueType, value = Integer, int64(v)
+			} else {
+				valueType = Number
+			}
+		default:
+			return pschema.ComplexTypeSpec{}, false
+		}
+
+		switch {
+		case enumType == "" || enumType == valueType:
+			enumType = valueType
+		case enumType == Integer && valueType == Number, enumType == Number && valueType == Integer:
+			enumType = Number
+		default:
+			return pschema.ComplexTypeSpec{}, false
+		}
+		normalized = append(normalized, value)
+	}
+	if enumType == "" || enumType == Boolean {
+		return pschema.ComplexTypeSpec{}, false
+	}
+	if enumType == Integer && schemaType == Number {
+		enumType = Number
+	}
+
+	descriptions := map[string]string{}
+	for _, match := range enumValueDescriptionRegex.FindAllStringSubmatch(description, -1) {
+		descriptions[match[1]] = strings.TrimSpace(match[2])
+	}
+
+	names := map[string]int{}
+	enum := make([]pschema.EnumValueSpec, 0, len(normalized))
+	for _, value := range normalized {
+		if enumType == Number {
+			value = toFloat(value)
+		}
+		name := enumValueName(value)
+		if names[name]++; names[name] > 1 {
+			name += strconv.Itoa(names[name])
+		}
+		enum = append(enum, pschema.EnumValueSpec{
+			Name:        name,
+			Description: descriptions[fmt.Sprint(value)],
+			Value:       value,
+		})
+	}
+
+	return pschema.ComplexTypeSpec{
+		ObjectTypeSpec: pschema.ObjectTypeSpec{
+			Type:        enumType,
+			Description: description,
+		},
+		Enum: enum,
+	}, true
+}
+
+// enumValueName returns an identifier for an enum value, e.g. "ClusterIssuer"
+// for "cluster-issuer" or "Value1" for 1.
+func enumValueName(value any) string {
+	s, isString := value.(string)
+	if !isString {
+		s = strings.NewReplacer("-", "Minus", ".", "Point").Replace(fmt.Sprint(value))
+	}
+	s = cgstrings.Unhyphenate(s)
+	s = cgstrings.ModifyStringAroundDelimeter(s, "_", cgstrings.UppercaseFirst)
+	s = cgstrings.UppercaseFirst(nonAlphanumericRegex.ReplaceAllString(s, ""))
+	switch {
+	case s == "":
+		return "Empty"
+	case !isString || s[0] >= '0' && s[0] <= '9':
+		return "Value" + s
+	}
+	return s
+}
+
+func toFloat(value any) float64 {
+	if v, ok := value.(int64); ok {
+		return float64(v)
+	}
+	return value.(float64)
+}
@@ -0,0 +1,210 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"reflect"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestNewEnumType(t *testing.T) {
+	pullPolicy := "Image pull policy.\n\nPossible enum values:\n" +
+		" - `\"Always\"` means that kubelet always attempts to pull the latest image.\n" +
+		" - `\"IfNotPresent\"` means that kubelet pulls if the image isn't present on disk.\n"
+
+	tests := []struct {
+		name        string
+		values      []any
+		schemaType  string
+		description string
+		want        []pschema.EnumValueSpec
+		wantType    string
+	}{
+		{
+			name:        "Strings",
+			values:      []any{"Always", "IfNotPresent", "cluster-issuer", ""},
+			schemaType:  String,
+			description: pullPolicy,
+			wantType:    String,
+			want: []pschema.EnumValueSpec{
+				{Name: "Always", Value: "Always", Description: "means that kubelet always attempts to pull the latest image."},
+				{Name: "IfNotPresent", Value: "IfNotPresent", Description: "means that kubelet pulls if the image isn't present on disk."},
+				{Name: "ClusterIssuer", Value: "cluster-issuer"},
+				{Name: "Empty", Value: ""},
+			},
+		},
+		{
+			name:       "Duplicate names",
+			values:     []any{"http", "HTTP", "3des"},
+			schemaType: String,
+			wantType:   String,
+			want: []pschema.EnumValueSpec{
+				{Name: "Http", Value: "http"},
+				{Name: "HTTP", Value: "HTTP"},
+				{Name: "Value3des", Value: "3des"},
+			},
+		},
+		{
+			name:       "Integers",
+			values:     []any{float64(1), int64(-2)},
+			schemaType: Integer,
+			wantType:   Integer,
+			want: []pschema.EnumValueSpec{
+				{Name: "Value1", Value: int64(1)},
+				{Name: "ValueMinus2", Value: int64(-2)},
+			},
+		},
+		{
+			name:       "Numbers",
+			values:     []any{float64(1), 1.5},
+			schemaType: Number,
+			wantType:   Number,
+			want: []pschema.EnumValueSpec{
+				{Name: "Value1", Value: float64(1)},
+				{Name: "Value1Point5", Value: 1.5},
+			},
+		},
+		{
+			name:       "Whole numbers",
+			values:     []any{float64(1), float64(2)},
+			schemaType: Number,
+			wantType:   Number,
+			want: []pschema.EnumValueSpec{
+				{Name: "Value1", Value: float64(1)},
+				{Name: "Value2", Value: float64(2)},
+			},
+		},
+		{
+			name:   "Mixed",
+			values: []any{"Always", float64(1)},
+		},
+		{
+			name:   "Booleans",
+			values: []any{true, false},
+		},
+		{
+			name:   "Null",
+			values: []any{"Always", nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enum, ok := newEnumType(tt.values, tt.schemaType, tt.description)
+			if ok != (tt.wantType != "") {
+				t.Fatalf("expected ok to be %v, got %v", tt.wantType != "", ok)
+			}
+			if !ok {
+				return
+			}
+			if enum.Type != tt.wantType {
+				t.Errorf("expected type %s, got %s", tt.wantType, enum.Type)
+			}
+			if !reflect.DeepEqual(tt.want, enum.Enum) {
+				t.Errorf("expected enum values %v, got %v", tt.want, enum.Enum)
+			}
+		})
+	}
+}
+
+func TestAddEnums(t *testing.T) {
+	stringEnum := func(values ...string) []extensionv1.JSON {
+		enum := make([]extensionv1.JSON, 0, len(values))
+		for _, v := range values {
+			enum = append(enum, extensionv1.JSON{Raw: []byte(`"` + v + `"`)})
+		}
+		return enum
+	}
+	issuerRef := extensionv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"kind": {Type: "string", Enum: stringEnum("Issuer", "ClusterIssuer")},
+			"name": {Type: "string"},
+		},
+	}
+	crd := filterTestCRD("cert-manager.io", "Certificate")
+	crd.Spec.Versions = []extensionv1.CustomResourceDefinitionVersion{{
+		Name: "v1",
+		Schema: &extensionv1.CustomResourceValidation{OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]extensionv1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]extensionv1.JSONSchemaProps{
+						"issuerRef": issuerRef,
+						"usages": {
+							Type:  "array",
+							Items: &extensionv1.JSONSchemaPropsOrArray{Schema: &extensionv1.JSONSchemaProps{Type: "string", Enum: stringEnum("digital signature", "server auth")}},
+						},
+						"mixed": {Type: "string", Enum: []extensionv1.JSON{{Raw: []byte(`"a"`)}, {Raw: []byte(`1`)}}},
+					},
+				},
+			},
+		}},
+	}}
+
+	stringType := pschema.TypeSpec{Type: String}
+	specProperties := func() map[string]pschema.PropertySpec {
+		return map[string]pschema.PropertySpec{
+			"issuerRef": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: "#/types/kubernetes:cert-manager.io/v1:CertificateSpecIssuerRef"}},
+			"usages":    {TypeSpec: pschema.TypeSpec{Type: Array, Items: &stringType}},
+			"mixed":     {TypeSpec: stringType},
+		}
+	}
+	pkgSpec := &pschema.PackageSpec{
+		Resources: map[string]pschema.ResourceSpec{
+			"kubernetes:cert-manager.io/v1:Certificate": {
+				InputProperties: map[string]pschema.PropertySpec{
+					"spec": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: "#/types/kubernetes:cert-manager.io/v1:CertificateSpec"}},
+				},
+			},
+		},
+		Types: map[string]pschema.ComplexTypeSpec{
+			"kubernetes:cert-manager.io/v1:CertificateSpec": {ObjectTypeSpec: pschema.ObjectTypeSpec{
+				Type:       Object,
+				Properties: specProperties(),
+			}},
+			"kubernetes:cert-manager.io/v1:CertificateSpecIssuerRef": {ObjectTypeSpec: pschema.ObjectTypeSpec{
+				Type:       Object,
+				Properties: map[string]pschema.PropertySpec{"kind": {TypeSpec: stringType}, "name": {TypeSpec: stringType}},
+			}},
+		},
+	}
+	addEnums(pkgSpec, crd)
+
+	kindRef := "#/types/kubernetes:cert-manager.io/v1:CertificateSpecIssuerRefKind"
+	if got := pkgSpec.Types["kubernetes:cert-manager.io/v1:CertificateSpecIssuerRef"].Properties["kind"].Ref; got != kindRef {
+		t.Errorf("expected issuerRef.kind to reference %s, got %q", kindRef, got)
+	}
+	if got := pkgSpec.Types["kubernetes:cert-manager.io/v1:CertificateSpecIssuerRef"].Properties["name"].Ref; got != "" {
+		t.Errorf("expected issuerRef.name to stay a string, got a reference to %s", got)
+	}
+	kind := pkgSpec.Types["kubernetes:cert-manager.io/v1:CertificateSpecIssuerRefKind"]
+	if kind.Type != String || len(kind.Enum) != 2 || kind.Enum[1].Value != "ClusterIssuer" {
+		t.Errorf("unexpected issuerRef.kind enum type %v", kind)
+	}
+
+	spec := pkgSpec.Types["kubernetes:cert-manager.io/v1:CertificateSpec"].Properties
+	usagesRef := "#/types/kubernetes:cert-manager.io/v1:CertificateSpecUsages"
+	if got := spec["usages"].Items.Ref; got != usagesRef {
+		t.Errorf("expected usages items to reference %s, got %q", usagesRef, got)
+	}
+	if got := spec["mixed"].TypeSpec; !reflect.DeepEqual(stringType, got) {
+		t.Errorf("expected mixed enum to stay a string, got %v", got)
+	}
+}
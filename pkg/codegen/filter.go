@@ -0,0 +1,216 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// VersionPolicy selects which versions of each CRD are generated, based on
+// their `served` and `storage` fields.
+type VersionPolicy string
+
+const (
+	// VersionPolicyAll generates every version of a CRD. This is the default.
+	VersionPolicyAll VersionPolicy = "all"
+	// VersionPolicyServed generates only the versions with `served: true`.
+	VersionPolicyServed VersionPolicy = "served"
+	// VersionPolicyStorage generates only the version with `storage: true`.
+	VersionPolicyStorage VersionPolicy = "storage"
+	// VersionPolicyLatestServed generates only the highest priority served
+	// version, e.g. v1 over v1beta1.
+	VersionPolicyLatestServed VersionPolicy = "latest-served"
+)
+
+// VersionPolicies are the supported version policies.
+var VersionPolicies = []VersionPolicy{VersionPolicyAll, VersionPolicyServed, VersionPolicyStorage, VersionPolicyLatestServed}
+
+// Filter selects the CRDs, and the versions of each CRD, that code is generated
+// for. Groups and kinds are matched against glob patterns such as
+// "*.cert-manager.io". An empty Filter selects everything.
+type Filter struct {
+	// IncludeGroups selects only the CRDs whose group matches one of the patterns
+	IncludeGroups []string
+	// ExcludeGroups skips the CRDs whose group matches one of the patterns
+	ExcludeGroups []string
+	// IncludeKinds selects only the CRDs whose kind matches one of the patterns
+	IncludeKinds []string
+	// ExcludeKinds skips the CRDs whose kind matches one of the patterns
+	ExcludeKinds []string
+	// Versions selects only the CRD versions with one of these names
+	Versions []string
+	// VersionPolicy selects the CRD versions by their served and storage
+	// fields. It is applied after Versions, and defaults to VersionPolicyAll
+	VersionPolicy VersionPolicy
+}
+
+// SkippedCRD describes a CRD, or some versions of a CRD, that were not
+// generated.
+type SkippedCRD struct {
+	// Name is the `metadata.name` of the CRD
+	Name string
+	// Versions are the skipped versions. If the CRD was skipped entirely, it
+	// contains every version of the CRD
+	Versions []string
+	// Reason describes why the CRD or versions were skipped
+	Reason string
+}
+
+func (s SkippedCRD) String() string {
+	return fmt.Sprintf("%s (%s): %s", s.Name, strings.Join(s.Versions, ", "), s.Reason)
+}
+
+// Validate returns an error if any of the filter's patterns is malformed, or
+// if its version policy is unknown.
+func (f Filter) Validate() error {
+	if f.VersionPolicy != "" && !slices.Contains(VersionPolicies, f.VersionPolicy) {
+		return fmt.Errorf("invalid version policy %q, must be one of %v", f.VersionPolicy, VersionPolicies)
+	}
+	for _, patterns := range [][]string{f.IncludeGroups, f.ExcludeGroups, f.IncludeKinds, f.ExcludeKinds} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Apply returns the CRDs selected by the filter, with their unselected versions
+// removed, and a description of everything that was skipped. The given CRDs are
+// not modified.
+func (f Filter) Apply(crds []extensionv1.CustomResourceDefinition) ([]extensionv1.CustomResourceDefinition, []SkippedCRD) {
+	selected := make([]extensionv1.CustomResourceDefinition, 0, len(crds))
+	var skipped []SkippedCRD
+	for _, crd := range crds {
+		if reason := f.skipReason(crd); reason != "" {
+			skipped = append(skipped, SkippedCRD{Name: crd.Name, Versions: versionNames(crd.Spec.Versions), Reason: reason})
+			continue
+		}
+
+		versions := crd.Spec.Versions
+		var skippedVersions []SkippedCRD
+		if len(f.Versions) > 0 {
+			var dropped []string
+			versions, dropped = partitionVersions(versions, func(v extensionv1.CustomResourceDefinitionVersion) bool {
+				return slices.Contains(f.Versions, v.Name)
+			})
+			if len(dropped) > 0 {
+				skippedVersions = append(skippedVersions, SkippedCRD{Name: crd.Name, Versions: dropped, Reason: "version not selected"})
+			}
+		}
+		if dropped, reason := f.VersionPolicy.apply(&versions); len(dropped) > 0 {
+			skippedVersions = append(skippedVersions, SkippedCRD{Name: crd.Name, Versions: dropped, Reason: reason})
+		}
+
+		if len(versions) == 0 {
+			skipped = append(skipped, SkippedCRD{Name: crd.Name, Versions: versionNames(crd.Spec.Versions), Reason: "no version selected"})
+			continue
+		}
+		skipped = append(skipped, skippedVersions...)
+		crd.Spec.Versions = versions
+
+		selected = append(selected, crd)
+	}
+	return selected, skipped
+}
+
+// apply removes the versions not selected by the policy from `versions`, and
+// returns their names and the reason they were removed.
+func (p VersionPolicy) apply(versions *[]extensionv1.CustomResourceDefinitionVersion) ([]string, string) {
+	var dropped []string
+	switch p {
+	case VersionPolicyServed:
+		*versions, dropped = partitionVersions(*versions, func(v extensionv1.CustomResourceDefinitionVersion) bool {
+			return v.Served
+		})
+		return dropped, "version not served"
+	case VersionPolicyStorage:
+		*versions, dropped = partitionVersions(*versions, func(v extensionv1.CustomResourceDefinitionVersion) bool {
+			return v.Storage
+		})
+		return dropped, "not the storage version"
+	case VersionPolicyLatestServed:
+		latest := ""
+		for _, v := range *versions {
+			if v.Served && (latest == "" || version.CompareKubeAwareVersionStrings(v.Name, latest) > 0) {
+				latest = v.Name
+			}
+		}
+		*versions, dropped = partitionVersions(*versions, func(v extensionv1.CustomResourceDefinitionVersion) bool {
+			return v.Name == latest
+		})
+		return dropped, "not the latest served version"
+	}
+	return nil, ""
+}
+
+// partitionVersions returns the versions for which `keep` returns true, and the
+// names of the others. The given slice is not modified.
+func partitionVersions(
+	versions []extensionv1.CustomResourceDefinitionVersion, keep func(extensionv1.CustomResourceDefinitionVersion) bool,
+) ([]extensionv1.CustomResourceDefinitionVersion, []string) {
+	var kept []extensionv1.CustomResourceDefinitionVersion
+	var dropped []string
+	for _, v := range versions {
+		if keep(v) {
+			kept = append(kept, v)
+		} else {
+			dropped = append(dropped, v.Name)
+		}
+	}
+	return kept, dropped
+}
+
+// skipReason returns why the whole CRD is skipped, or "" if it is selected.
+func (f Filter) skipReason(crd extensionv1.CustomResourceDefinition) string {
+	group, kind := crd.Spec.Group, crd.Spec.Names.Kind
+	switch {
+	case len(f.IncludeGroups) > 0 && !matchesAny(group, f.IncludeGroups):
+		return fmt.Sprintf("group %s not included", group)
+	case matchesAny(group, f.ExcludeGroups):
+		return fmt.Sprintf("group %s excluded", group)
+	case len(f.IncludeKinds) > 0 && !matchesAny(kind, f.IncludeKinds):
+		return fmt.Sprintf("kind %s not included", kind)
+	case matchesAny(kind, f.ExcludeKinds):
+		return fmt.Sprintf("kind %s excluded", kind)
+	}
+	return ""
+}
+
+// matchesAny returns true if `name` matches one of the glob patterns. Patterns
+// are expected to have been checked by Filter.Validate.
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func versionNames(versions []extensionv1.CustomResourceDefinitionVersion) []string {
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.Name)
+	}
+	return names
+}
@@ -0,0 +1,213 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"reflect"
+	"testing"
+
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func filterTestCRD(group, kind string, versions ...string) extensionv1.CustomResourceDefinition {
+	crd := extensionv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: kind + "." + group},
+		Spec: extensionv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: extensionv1.CustomResourceDefinitionNames{Kind: kind},
+		},
+	}
+	for _, v := range versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, extensionv1.CustomResourceDefinitionVersion{Name: v})
+	}
+	return crd
+}
+
+func TestFilterApply(t *testing.T) {
+	crds := []extensionv1.CustomResourceDefinition{
+		filterTestCRD("cert-manager.io", "Certificate", "v1", "v1alpha2"),
+		filterTestCRD("acme.cert-manager.io", "Challenge", "v1"),
+		filterTestCRD("acme.cert-manager.io", "Order", "v1alpha2"),
+		filterTestCRD("networking.istio.io", "Gateway", "v1"),
+	}
+
+	tests := []struct {
+		name         string
+		filter       Filter
+		wantSelected map[string][]string
+		wantSkipped  []SkippedCRD
+	}{
+		{
+			name:   "Empty filter",
+			filter: Filter{},
+			wantSelected: map[string][]string{
+				"Certificate.cert-manager.io":    {"v1", "v1alpha2"},
+				"Challenge.acme.cert-manager.io": {"v1"},
+				"Order.acme.cert-manager.io":     {"v1alpha2"},
+				"Gateway.networking.istio.io":    {"v1"},
+			},
+		},
+		{
+			name:   "Include group glob and exclude kind",
+			filter: Filter{IncludeGroups: []string{"*.cert-manager.io"}, ExcludeKinds: []string{"Challenge"}},
+			wantSelected: map[string][]string{
+				"Order.acme.cert-manager.io": {"v1alpha2"},
+			},
+			wantSkipped: []SkippedCRD{
+				{Name: "Certificate.cert-manager.io", Versions: []string{"v1", "v1alpha2"}, Reason: "group cert-manager.io not included"},
+				{Name: "Challenge.acme.cert-manager.io", Versions: []string{"v1"}, Reason: "kind Challenge excluded"},
+				{Name: "Gateway.networking.istio.io", Versions: []string{"v1"}, Reason: "group networking.istio.io not included"},
+			},
+		},
+		{
+			name:   "Exclude group and include kind",
+			filter: Filter{ExcludeGroups: []string{"acme.*"}, IncludeKinds: []string{"C*"}},
+			wantSelected: map[string][]string{
+				"Certificate.cert-manager.io": {"v1", "v1alpha2"},
+			},
+			wantSkipped: []SkippedCRD{
+				{Name: "Challenge.acme.cert-manager.io", Versions: []string{"v1"}, Reason: "group acme.cert-manager.io excluded"},
+				{Name: "Order.acme.cert-manager.io", Versions: []string{"v1alpha2"}, Reason: "group acme.cert-manager.io excluded"},
+				{Name: "Gateway.networking.istio.io", Versions: []string{"v1"}, Reason: "kind Gateway not included"},
+			},
+		},
+		{
+			name:   "Versions",
+			filter: Filter{Versions: []string{"v1"}},
+			wantSelected: map[string][]string{
+				"Certificate.cert-manager.io":    {"v1"},
+				"Challenge.acme.cert-manager.io": {"v1"},
+				"Gateway.networking.istio.io":    {"v1"},
+			},
+			wantSkipped: []SkippedCRD{
+				{Name: "Certificate.cert-manager.io", Versions: []string{"v1alpha2"}, Reason: "version not selected"},
+				{Name: "Order.acme.cert-manager.io", Versions: []string{"v1alpha2"}, Reason: "no version selected"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, skipped := tt.filter.Apply(crds)
+			gotSelected := map[string][]string{}
+			for _, crd := range selected {
+				gotSelected[crd.Name] = versionNames(crd.Spec.Versions)
+			}
+			if !reflect.DeepEqual(tt.wantSelected, gotSelected) {
+				t.Errorf("expected selected %v, got %v", tt.wantSelected, gotSelected)
+			}
+			if !reflect.DeepEqual(tt.wantSkipped, skipped) {
+				t.Errorf("expected skipped %v, got %v", tt.wantSkipped, skipped)
+			}
+		})
+	}
+
+	if len(crds[0].Spec.Versions) != 2 {
+		t.Errorf("expected Apply to leave the given CRDs unmodified")
+	}
+}
+
+func TestFilterVersionPolicy(t *testing.T) {
+	crd := filterTestCRD("stable.example.com", "CronTab")
+	crd.Spec.Versions = []extensionv1.CustomResourceDefinitionVersion{
+		{Name: "v1alpha1", Served: false},
+		{Name: "v1beta1", Served: true, Storage: true},
+		{Name: "v1", Served: true},
+		{Name: "v2alpha1", Served: true},
+	}
+
+	tests := []struct {
+		policy       VersionPolicy
+		versions     []string
+		wantSelected []string
+		wantSkipped  []SkippedCRD
+	}{
+		{
+			policy:       "",
+			wantSelected: []string{"v1alpha1", "v1beta1", "v1", "v2alpha1"},
+		},
+		{
+			policy:       VersionPolicyAll,
+			wantSelected: []string{"v1alpha1", "v1beta1", "v1", "v2alpha1"},
+		},
+		{
+			policy:       VersionPolicyServed,
+			wantSelected: []string{"v1beta1", "v1", "v2alpha1"},
+			wantSkipped: []SkippedCRD{
+				{Name: crd.Name, Versions: []string{"v1alpha1"}, Reason: "version not served"},
+			},
+		},
+		{
+			policy:       VersionPolicyStorage,
+			wantSelected: []string{"v1beta1"},
+			wantSkipped: []SkippedCRD{
+				{Name: crd.Name, Versions: []string{"v1alpha1", "v1", "v2alpha1"}, Reason: "not the storage version"},
+			},
+		},
+		{
+			policy:       VersionPolicyLatestServed,
+			wantSelected: []string{"v1"},
+			wantSkipped: []SkippedCRD{
+				{Name: crd.Name, Versions: []string{"v1alpha1", "v1beta1", "v2alpha1"}, Reason: "not the latest served version"},
+			},
+		},
+		{
+			policy:       VersionPolicyLatestServed,
+			versions:     []string{"v1alpha1", "v1beta1"},
+			wantSelected: []string{"v1beta1"},
+			wantSkipped: []SkippedCRD{
+				{Name: crd.Name, Versions: []string{"v1", "v2alpha1"}, Reason: "version not selected"},
+				{Name: crd.Name, Versions: []string{"v1alpha1"}, Reason: "not the latest served version"},
+			},
+		},
+		{
+			policy:   VersionPolicyServed,
+			versions: []string{"v1alpha1"},
+			wantSkipped: []SkippedCRD{
+				{Name: crd.Name, Versions: []string{"v1alpha1", "v1beta1", "v1", "v2alpha1"}, Reason: "no version selected"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			filter := Filter{Versions: tt.versions, VersionPolicy: tt.policy}
+			selected, skipped := filter.Apply([]extensionv1.CustomResourceDefinition{crd})
+			var gotSelected []string
+			for _, crd := range selected {
+				gotSelected = versionNames(crd.Spec.Versions)
+			}
+			if !reflect.DeepEqual(tt.wantSelected, gotSelected) {
+				t.Errorf("expected selected versions %v, got %v", tt.wantSelected, gotSelected)
+			}
+			if !reflect.DeepEqual(tt.wantSkipped, skipped) {
+				t.Errorf("expected skipped %v, got %v", tt.wantSkipped, skipped)
+			}
+		})
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	if err := (Filter{IncludeGroups: []string{"*.cert-manager.io"}}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := (Filter{ExcludeKinds: []string{"[Challenge"}}).Validate(); err == nil {
+		t.Errorf("expected an error for a malformed pattern")
+	}
+	if err := (Filter{VersionPolicy: "newest"}).Validate(); err == nil {
+		t.Errorf("expected an error for an unknown version policy")
+	}
+}
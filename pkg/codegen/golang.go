@@ -47,11 +47,6 @@ func GenerateGo(pg *PackageGenerator, cs *CodegenSettings) (buffers map[string]*
 	langName := "go"
 	oldName := pkg.Name
 	pkg.Name = cs.PackageName
-	moduleToPackage, err := pg.ModuleToPackage()
-	if err != nil {
-		return nil, fmt.Errorf("%w", err)
-	}
-	moduleToPackage["meta/v1"] = "meta/v1"
 
 	files, err := goGen.GeneratePackage("crd2pulumi", pkg, nil)
 	if err != nil {
@@ -61,6 +56,20 @@ func GenerateGo(pg *PackageGenerator, cs *CodegenSettings) (buffers map[string]*
 	pkg.Name = oldName
 	delete(pkg.Language, langName)
 
+	if err := checkClusterScopedNamespaces(pg, Go, files); err != nil {
+		return nil, err
+	}
+	if cs.ValidationHelpers {
+		if err := addValidationHelpers(pg, Go, "", files); err != nil {
+			return nil, err
+		}
+	}
+	if pg.lookupFunctions {
+		if err := writeLookupFunctions(pg, Go, files); err != nil {
+			return nil, err
+		}
+	}
+
 	buffers = map[string]*bytes.Buffer{}
 	for path, code := range files {
 		if !UnneededGoFiles.Has(path) {
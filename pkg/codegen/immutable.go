@@ -0,0 +1,203 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// WithImmutable makes the generated resources replace themselves when the
+// given properties change, in addition to the properties the CRD schemas make
+// immutable with x-kubernetes-validations rules such as `self == oldSelf`. Each
+// field is a property path such as "spec.storageClassName", which applies to
+// every kind, or the path of a single kind such as
+// "Certificate.spec.secretName". Items of arrays are selected with "[*]", e.g.
+// "spec.volumes[*].name". A field prefixed with "!" stops a detected property
+// from causing a replacement.
+func WithImmutable(fields []string) PackageOption {
+	return func(opts *packageOptions) {
+		opts.immutable = fields
+	}
+}
+
+// validateImmutable returns an error if any of the immutable fields is not a
+// property path, optionally qualified by a kind.
+func validateImmutable(fields []string) error {
+	for _, field := range fields {
+		_, path := splitImmutableField(strings.TrimPrefix(field, "!"))
+		valid := path != ""
+		for _, name := range strings.Split(path, ".") {
+			if strings.TrimSuffix(name, "[*]") == "" || strings.ContainsAny(strings.TrimSuffix(name, "[*]"), "[]*") {
+				valid = false
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid immutable field %q: must be a property path such as spec.storageClassName, "+
+				"optionally qualified by a kind such as Certificate.spec.secretName", field)
+		}
+	}
+	return nil
+}
+
+// splitImmutableField splits `field` into the kind it applies to, or "" for
+// every kind, and its property path. Kinds start with an upper case letter.
+func splitImmutableField(field string) (kind, path string) {
+	if first, rest, ok := strings.Cut(field, "."); ok && first != "" && unicode.IsUpper(rune(first[0])) {
+		return first, rest
+	}
+	return "", field
+}
+
+// immutableFields returns the paths of the properties of `kind` that are
+// replaced on changes: the `detected` paths and the paths in `fields` that
+// apply to `kind`, less the paths excluded with "!".
+func immutableFields(kind string, detected, fields []string) []string {
+	paths := slices.Clone(detected)
+	var excluded []string
+	for _, field := range fields {
+		exclude := strings.HasPrefix(field, "!")
+		k, path := splitImmutableField(strings.TrimPrefix(field, "!"))
+		if k != "" && k != kind {
+			continue
+		}
+		if exclude {
+			excluded = append(excluded, path)
+		} else {
+			paths = append(paths, path)
+		}
+	}
+	paths = slices.DeleteFunc(paths, func(path string) bool { return slices.Contains(excluded, path) })
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// immutableRule matches the CEL rules that forbid changing a value, either
+// `self == oldSelf` on the value itself or `self.x == oldSelf.x` on its parent.
+var immutableRule = regexp.MustCompile(`^(?:self((?:\.\w+)*)==oldSelf((?:\.\w+)*)|oldSelf((?:\.\w+)*)==self((?:\.\w+)*))$`)
+
+// immutablePaths returns the paths of the properties of `schema` whose
+// x-kubernetes-validations rules make them immutable. The metadata is skipped,
+// since the API server already forbids changing the name and namespace.
+func immutablePaths(schema extensionv1.JSONSchemaProps) []string {
+	var paths []string
+	var walk func(schema extensionv1.JSONSchemaProps, path string)
+	walk = func(schema extensionv1.JSONSchemaProps, path string) {
+		for _, rule := range schema.XValidations {
+			match := immutableRule.FindStringSubmatch(strings.Join(strings.Fields(rule.Rule), ""))
+			if match == nil || match[1]+match[3] != match[2]+match[4] {
+				continue
+			}
+			subpath := strings.TrimPrefix(match[1]+match[3], ".")
+			switch {
+			case subpath == "":
+				if path != "" {
+					paths = append(paths, path)
+				}
+			case path == "":
+				paths = append(paths, subpath)
+			default:
+				paths = append(paths, path+"."+subpath)
+			}
+		}
+		for name, property := range schema.Properties {
+			if path == "" && name == "metadata" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			walk(property, name)
+		}
+		if schema.Items != nil && schema.Items.Schema != nil {
+			walk(*schema.Items.Schema, path+"[*]")
+		}
+	}
+	walk(schema, "")
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
+
+// setReplaceOnChanges marks the immutable properties of every resource
+// ReplaceOnChanges, so the generated SDKs replace the resource instead of
+// updating it in place when they change, which the API server would reject.
+// Patch resources are not marked, since replacing them doesn't replace the
+// patched resource.
+func (pg *PackageGenerator) setReplaceOnChanges(pkgSpec *pschema.PackageSpec) {
+	for _, crg := range pg.CustomResourceGenerators {
+		for _, v := range crg.CustomResourceDefinition.Spec.Versions {
+			if !slices.Contains(crg.Versions, v.Name) {
+				continue
+			}
+			var detected []string
+			if v.Schema != nil && v.Schema.OpenAPIV3Schema != nil {
+				detected = immutablePaths(*v.Schema.OpenAPIV3Schema)
+			}
+			token := getToken(crg.Group, v.Name, crg.Kind)
+			resource, ok := pkgSpec.Resources[token]
+			if !ok {
+				continue
+			}
+			scope := getToken(crg.Group, v.Name, "")
+			for _, path := range immutableFields(crg.Kind, detected, pg.immutable) {
+				names := strings.Split(path, ".")
+				// Output-only properties can't be changed, so they are never replaced on changes.
+				if _, ok := resource.InputProperties[strings.TrimSuffix(names[0], "[*]")]; !ok {
+					continue
+				}
+				markReplaceOnChanges(pkgSpec, scope, resource.InputProperties, names)
+				markReplaceOnChanges(pkgSpec, scope, resource.Properties, names)
+			}
+			pkgSpec.Resources[token] = resource
+		}
+	}
+}
+
+// markReplaceOnChanges sets ReplaceOnChanges on the property at the path
+// `names` of `properties`, following the object types in `scope` the
+// properties refer to. Paths that don't exist are ignored.
+func markReplaceOnChanges(pkgSpec *pschema.PackageSpec, scope string, properties map[string]pschema.PropertySpec, names []string) {
+	name := strings.TrimSuffix(names[0], "[*]")
+	property, ok := properties[name]
+	if !ok {
+		return
+	}
+	if len(names) == 1 {
+		property.ReplaceOnChanges = true
+		properties[name] = property
+		return
+	}
+
+	typeSpec := property.TypeSpec
+	if strings.HasSuffix(names[0], "[*]") {
+		if typeSpec.Items == nil {
+			return
+		}
+		typeSpec = *typeSpec.Items
+	}
+	if !strings.HasPrefix(typeSpec.Ref, typeRefPrefix+scope) {
+		return
+	}
+	token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+	if typ, ok := pkgSpec.Types[token]; ok {
+		markReplaceOnChanges(pkgSpec, scope, typ.Properties, names[1:])
+	}
+}
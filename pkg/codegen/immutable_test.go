@@ -0,0 +1,155 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"reflect"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func immutableTestSchema() extensionv1.JSONSchemaProps {
+	rule := func(rule string) extensionv1.ValidationRules {
+		return extensionv1.ValidationRules{{Rule: rule, Message: "is immutable"}}
+	}
+	return extensionv1.JSONSchemaProps{
+		Type: Object,
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"metadata": {Type: Object, XValidations: rule("self.name == oldSelf.name")},
+			"spec": {
+				Type:         Object,
+				XValidations: rule("self.storageClassName == oldSelf.storageClassName"),
+				Properties: map[string]extensionv1.JSONSchemaProps{
+					"storageClassName": {Type: String},
+					"selector":         {Type: Object, XValidations: rule("oldSelf == self")},
+					"replicas":         {Type: Integer, XValidations: rule("self >= oldSelf")},
+					"schedule":         {Type: String, XValidations: rule("self.a == oldSelf.b")},
+					"volumes": {
+						Type: Array,
+						Items: &extensionv1.JSONSchemaPropsOrArray{Schema: &extensionv1.JSONSchemaProps{
+							Type: Object,
+							Properties: map[string]extensionv1.JSONSchemaProps{
+								"name": {Type: String, XValidations: rule("self==oldSelf")},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestImmutablePaths(t *testing.T) {
+	want := []string{"spec.selector", "spec.storageClassName", "spec.volumes[*].name"}
+	if got := immutablePaths(immutableTestSchema()); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestValidateImmutable(t *testing.T) {
+	for _, field := range []string{"spec", "spec.storageClassName", "CronTab.spec.image", "!spec.volumes[*].name"} {
+		if err := validateImmutable([]string{field}); err != nil {
+			t.Errorf("unexpected error for %q: %v", field, err)
+		}
+	}
+	for _, field := range []string{"", "!", "spec.", ".spec", "CronTab.", "spec[0]", "spec.*"} {
+		if err := validateImmutable([]string{field}); err == nil {
+			t.Errorf("expected an error for %q", field)
+		}
+	}
+}
+
+func TestImmutableFields(t *testing.T) {
+	detected := []string{"spec.selector", "spec.storageClassName"}
+	fields := []string{"spec.image", "CronTab.spec.schedule", "Other.spec.replicas", "!CronTab.spec.selector", "!Other.spec.storageClassName"}
+	want := []string{"spec.image", "spec.schedule", "spec.storageClassName"}
+	if got := immutableFields("CronTab", detected, fields); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSetReplaceOnChanges(t *testing.T) {
+	properties := func() map[string]pschema.PropertySpec {
+		return map[string]pschema.PropertySpec{
+			"spec":   {TypeSpec: pschema.TypeSpec{Ref: "#/types/kubernetes:stable.example.com/v1:CronTabSpec"}},
+			"status": {TypeSpec: pschema.TypeSpec{Type: Object}},
+		}
+	}
+	pkgSpec := &pschema.PackageSpec{
+		Resources: map[string]pschema.ResourceSpec{
+			"kubernetes:stable.example.com/v1:CronTab": {
+				ObjectTypeSpec:  pschema.ObjectTypeSpec{Properties: properties()},
+				InputProperties: properties(),
+			},
+		},
+		Types: map[string]pschema.ComplexTypeSpec{
+			"kubernetes:stable.example.com/v1:CronTabSpec": {ObjectTypeSpec: pschema.ObjectTypeSpec{
+				Properties: map[string]pschema.PropertySpec{
+					"storageClassName": {TypeSpec: pschema.TypeSpec{Type: String}},
+					"selector":         {TypeSpec: pschema.TypeSpec{Type: Object}},
+					"volumes": {TypeSpec: pschema.TypeSpec{
+						Type:  Array,
+						Items: &pschema.TypeSpec{Ref: "#/types/kubernetes:stable.example.com/v1:CronTabSpecVolumes"},
+					}},
+				},
+			}},
+			"kubernetes:stable.example.com/v1:CronTabSpecVolumes": {ObjectTypeSpec: pschema.ObjectTypeSpec{
+				Properties: map[string]pschema.PropertySpec{
+					"name": {TypeSpec: pschema.TypeSpec{Type: String}},
+				},
+			}},
+		},
+	}
+	delete(pkgSpec.Resources["kubernetes:stable.example.com/v1:CronTab"].InputProperties, "status")
+
+	schema := immutableTestSchema()
+	pg := &PackageGenerator{
+		CustomResourceGenerators: []CustomResourceGenerator{{
+			CustomResourceDefinition: extensionv1.CustomResourceDefinition{Spec: extensionv1.CustomResourceDefinitionSpec{
+				Versions: []extensionv1.CustomResourceDefinitionVersion{{
+					Name:   "v1",
+					Schema: &extensionv1.CustomResourceValidation{OpenAPIV3Schema: &schema},
+				}},
+			}},
+			Group:    "stable.example.com",
+			Kind:     "CronTab",
+			Versions: []string{"v1"},
+		}},
+		immutable: []string{"!spec.selector", "status", "CronTab.spec.missing"},
+	}
+	pg.setReplaceOnChanges(pkgSpec)
+
+	resource := pkgSpec.Resources["kubernetes:stable.example.com/v1:CronTab"]
+	if resource.InputProperties["spec"].ReplaceOnChanges || resource.Properties["status"].ReplaceOnChanges {
+		t.Errorf("expected only nested properties to be replaced on changes")
+	}
+	tests := []struct {
+		token    string
+		property string
+		want     bool
+	}{
+		{"kubernetes:stable.example.com/v1:CronTabSpec", "storageClassName", true},
+		{"kubernetes:stable.example.com/v1:CronTabSpec", "selector", false},
+		{"kubernetes:stable.example.com/v1:CronTabSpec", "volumes", false},
+		{"kubernetes:stable.example.com/v1:CronTabSpecVolumes", "name", true},
+	}
+	for _, tt := range tests {
+		if got := pkgSpec.Types[tt.token].Properties[tt.property].ReplaceOnChanges; got != tt.want {
+			t.Errorf("expected %s.%s ReplaceOnChanges to be %v, got %v", tt.token, tt.property, tt.want, got)
+		}
+	}
+}
@@ -20,42 +20,33 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/pulumi/crd2pulumi/internal/versions"
 	javaGen "github.com/pulumi/pulumi-java/pkg/codegen/java"
 )
 
 func GenerateJava(pg *PackageGenerator, cs *CodegenSettings) (map[string]*bytes.Buffer, error) {
 	pkg := pg.SchemaPackageWithObjectMetaType()
+	langName := "java"
 
 	// These fields are required for the Java code generation
 	pkg.Description = "Generated Java SDK via crd2pulumi"
 	pkg.Repository = "Placeholder"
 
 	// Set up packages
-	packages := map[string]string{}
-	for _, groupVersion := range pg.GroupVersions {
-		group, version, err := versions.SplitGroupVersion(groupVersion)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version: %w", err)
-		}
-		groupPrefix, err := versions.GroupPrefix(group)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version: %w", err)
-		}
-		packages[groupVersion] = groupPrefix + "." + version
+	packages, err := pg.languageModules(langName)
+	if err != nil {
+		return nil, err
 	}
-	packages["meta/v1"] = "meta.v1"
 
-	langName := "java"
 	oldName := pkg.Name
 	pkg.Name = cs.PackageName
 	pkg.Namespace = cs.PackageNamespace
 
-	// Override Java base package to avoid pulumi-java defaulting logic adding an extra "com." prefix
+	// Override Java base package to avoid pulumi-java defaulting logic adding an extra "com." prefix, and map each
+	// module to its package
 	if pkg.Language == nil {
 		pkg.Language = map[string]interface{}{}
 	}
-	pkg.Language[langName] = javaGen.PackageInfo{BasePackage: cs.PackageNamespace}
+	pkg.Language[langName] = javaGen.PackageInfo{BasePackage: cs.PackageNamespace, Packages: packages}
 
 	namespacePath := "com/pulumi"
 	if cs.PackageNamespace != "" {
@@ -93,6 +84,12 @@ func GenerateJava(pg *PackageGenerator, cs *CodegenSettings) (map[string]*bytes.
 		delete(files, unneededFile)
 	}
 
+	if pg.lookupFunctions {
+		if err := writeLookupFunctions(pg, Java, files); err != nil {
+			return nil, err
+		}
+	}
+
 	buffers := map[string]*bytes.Buffer{}
 	for name, code := range files {
 		buffers[name] = bytes.NewBuffer(code)
@@ -23,6 +23,9 @@ var SupportedLanguages = []string{
 	Go,
 	NodeJS,
 	Python,
+	Schema,
+	Docs,
+	YAML,
 }
 
 const DotNet string = "dotnet"
@@ -30,6 +33,9 @@ const Go string = "go"
 const NodeJS string = "nodejs"
 const Python string = "python"
 const Java string = "java"
+const Schema string = "schema"
+const Docs string = "docs"
+const YAML string = "yaml"
 
 type CodegenSettings struct {
 	Language         string
@@ -38,7 +44,14 @@ type CodegenSettings struct {
 	PackageNamespace string
 	PackageVersion   string
 	Overwrite        bool
+	Prune            bool
 	ShouldGenerate   bool
+	SchemaFormat     string
+	DocsFormat       string
+	// ValidationHelpers adds a validation module that checks the arguments of
+	// resources against the validation constraints of their CRDs. Only NodeJS,
+	// Python and Go support it.
+	ValidationHelpers bool
 }
 
 func (cs *CodegenSettings) Path() string {
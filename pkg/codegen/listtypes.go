@@ -0,0 +1,134 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// describeListType returns a sentence describing how server-side apply, which
+// Patch resources use, merges a list with the given x-kubernetes-list-type and
+// x-kubernetes-list-map-keys. Lists without a list type are atomic.
+func describeListType(listType string, mapKeys []string) string {
+	switch listType {
+	case "map":
+		if len(mapKeys) == 0 {
+			return "Server-side apply merges the items of this list by key, so a Patch updates or adds items and keeps the others."
+		}
+		return "Server-side apply merges the items of this list by their " + joinKeys(mapKeys) + " keys, so a Patch " +
+			"updates the items with the same keys, adds new ones and keeps the others."
+	case "set":
+		return "Server-side apply merges the items of this list as a set, so a Patch adds its items and keeps the others."
+	case "atomic":
+		return "Server-side apply replaces this list as a whole, so a Patch must list every item."
+	case "":
+		return "Server-side apply replaces this list as a whole, as it has no `x-kubernetes-list-type`, so a Patch must " +
+			"list every item."
+	}
+	return ""
+}
+
+// describeMapType returns a sentence describing how server-side apply merges
+// an object with the given x-kubernetes-map-type, or "" if it isn't set.
+func describeMapType(mapType string) string {
+	switch mapType {
+	case "atomic":
+		return "Server-side apply replaces this object as a whole, so a Patch must set every field."
+	case "granular":
+		return "Server-side apply merges the fields of this object, so a Patch only needs to set the fields it changes."
+	}
+	return ""
+}
+
+// describeMergeKeys returns a Markdown list of the lists and objects in
+// `schema` that server-side apply doesn't simply merge field by field, including
+// the lists without a list type, which are atomic, for the descriptions of Patch
+// resources, or "" if there are none.
+func describeMergeKeys(schema extensionv1.JSONSchemaProps) string {
+	var lines []string
+	var walk func(schema extensionv1.JSONSchemaProps, path string)
+	walk = func(schema extensionv1.JSONSchemaProps, path string) {
+		switch {
+		case schema.XListType != nil && *schema.XListType == "map" && len(schema.XListMapKeys) > 0:
+			lines = append(lines, "- `"+path+"`: items merged by "+joinKeys(schema.XListMapKeys))
+		case schema.XListType != nil && *schema.XListType == "set":
+			lines = append(lines, "- `"+path+"`: items merged as a set")
+		case schema.XListType != nil && *schema.XListType == "atomic":
+			lines = append(lines, "- `"+path+"`: replaced as a whole")
+		case schema.XListType == nil && schema.Type == Array:
+			lines = append(lines, "- `"+path+"`: replaced as a whole, as it has no list type")
+		case schema.XMapType != nil && *schema.XMapType == "atomic":
+			lines = append(lines, "- `"+path+"`: replaced as a whole")
+		}
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			if path == "" && name == "metadata" {
+				continue
+			}
+			propertyPath := name
+			if path != "" {
+				propertyPath = path + "." + name
+			}
+			walk(schema.Properties[name], propertyPath)
+		}
+		if schema.Items != nil && schema.Items.Schema != nil {
+			walk(*schema.Items.Schema, path+"[*]")
+		}
+	}
+	walk(schema, "")
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(append([]string{"Server-side apply merge semantics:"}, lines...), "\n")
+}
+
+// addMergeKeys appends the merge semantics of the lists and objects of every
+// version of `crd` to the descriptions of its Patch resources.
+func addMergeKeys(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) {
+	for _, v := range crd.Spec.Versions {
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		token := getToken(crd.Spec.Group, v.Name, crd.Spec.Names.Kind) + "Patch"
+		if resource, ok := pkgSpec.Resources[token]; ok {
+			resource.Description = appendDocs(resource.Description, describeMergeKeys(*v.Schema.OpenAPIV3Schema))
+			pkgSpec.Resources[token] = resource
+		}
+	}
+}
+
+// joinKeys formats list map keys as "`a`", "`a` and `b`" or "`a`, `b` and `c`".
+func joinKeys(keys []string) string {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = "`" + key + "`"
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
+
+// deref returns the string `s` points to, or "" if it is nil.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,88 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestDescribeListType(t *testing.T) {
+	tests := []struct {
+		listType string
+		mapKeys  []string
+		want     string
+	}{
+		{"", nil, "Server-side apply replaces this list as a whole, as it has no `x-kubernetes-list-type`, so a Patch " +
+			"must list every item."},
+		{"map", []string{"name"}, "Server-side apply merges the items of this list by their `name` keys, so a Patch " +
+			"updates the items with the same keys, adds new ones and keeps the others."},
+		{"map", []string{"port", "protocol", "name"}, "Server-side apply merges the items of this list by their " +
+			"`port`, `protocol` and `name` keys, so a Patch updates the items with the same keys, adds new ones and keeps the others."},
+		{"set", nil, "Server-side apply merges the items of this list as a set, so a Patch adds its items and keeps the others."},
+		{"atomic", nil, "Server-side apply replaces this list as a whole, so a Patch must list every item."},
+	}
+	for _, tt := range tests {
+		if got := describeListType(tt.listType, tt.mapKeys); got != tt.want {
+			t.Errorf("expected %q for %s %v, got %q", tt.want, tt.listType, tt.mapKeys, got)
+		}
+	}
+}
+
+func TestAddMergeKeys(t *testing.T) {
+	str := func(s string) *string { return &s }
+	crd := filterTestCRD("gateway.networking.k8s.io", "HTTPRoute", "v1")
+	crd.Spec.Versions[0].Schema = &extensionv1.CustomResourceValidation{OpenAPIV3Schema: &extensionv1.JSONSchemaProps{
+		Type: Object,
+		Properties: map[string]extensionv1.JSONSchemaProps{
+			"metadata": {Type: Object, XMapType: str("atomic")},
+			"spec": {
+				Type: Object,
+				Properties: map[string]extensionv1.JSONSchemaProps{
+					"hostnames": {Type: Array, XListType: str("set")},
+					"rules": {
+						Type: Array,
+						Items: &extensionv1.JSONSchemaPropsOrArray{Schema: &extensionv1.JSONSchemaProps{
+							Type: Object,
+							Properties: map[string]extensionv1.JSONSchemaProps{
+								"matches": {Type: Array, XListType: str("map"), XListMapKeys: []string{"name", "port"}},
+								"filters": {Type: Object, XMapType: str("atomic")},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}}
+	pkgSpec := &pschema.PackageSpec{Resources: map[string]pschema.ResourceSpec{
+		"kubernetes:gateway.networking.k8s.io/v1:HTTPRoute":      {ObjectTypeSpec: pschema.ObjectTypeSpec{Description: "HTTPRoute."}},
+		"kubernetes:gateway.networking.k8s.io/v1:HTTPRoutePatch": {ObjectTypeSpec: pschema.ObjectTypeSpec{Description: "HTTPRoute."}},
+	}}
+	addMergeKeys(pkgSpec, crd)
+
+	want := "HTTPRoute.\n\nServer-side apply merge semantics:\n" +
+		"- `spec.hostnames`: items merged as a set\n" +
+		"- `spec.rules`: replaced as a whole, as it has no list type\n" +
+		"- `spec.rules[*].filters`: replaced as a whole\n" +
+		"- `spec.rules[*].matches`: items merged by `name` and `port`"
+	if got := pkgSpec.Resources["kubernetes:gateway.networking.k8s.io/v1:HTTPRoutePatch"].Description; got != want {
+		t.Errorf("expected Patch description %q, got %q", want, got)
+	}
+	if got := pkgSpec.Resources["kubernetes:gateway.networking.k8s.io/v1:HTTPRoute"].Description; got != "HTTPRoute." {
+		t.Errorf("expected the resource description to be unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// WithLookupFunctions adds a function that reads an existing resource by its
+// name and namespace for every generated resource, in every language.
+func WithLookupFunctions(enabled bool) PackageOption {
+	return func(opts *packageOptions) {
+		opts.lookupFunctions = enabled
+	}
+}
+
+// lookupFunctionPrefix prefixes the names of the lookup functions in the
+// package spec, like the get functions of other providers.
+const lookupFunctionPrefix = "get"
+
+// addLookupFunctions declares a lookup function in the package spec for every
+// resource generated from `crd`, taking the name and namespace of the object
+// and returning the resource's properties. The functions are overlays: the
+// Kubernetes provider only implements a fixed set of invokes, so the language
+// generators skip them and writeLookupFunctions adds their code, which wraps
+// the get method of the resource.
+func addLookupFunctions(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) {
+	kind := crd.Spec.Names.Kind
+	namespace := describeLookupNamespace(kind, crd.Spec.Scope == extensionv1.ClusterScoped)
+	for _, v := range crd.Spec.Versions {
+		resource, ok := pkgSpec.Resources[getToken(crd.Spec.Group, v.Name, kind)]
+		if !ok {
+			continue
+		}
+		if pkgSpec.Functions == nil {
+			pkgSpec.Functions = map[string]pschema.FunctionSpec{}
+		}
+		pkgSpec.Functions[getToken(crd.Spec.Group, v.Name, lookupFunctionPrefix+kind)] = pschema.FunctionSpec{
+			Description: "Reads an existing " + kind + " by its name and namespace. The resource is named " +
+				"\"<namespace>/<name>\" in the program.",
+			Inputs: &pschema.ObjectTypeSpec{
+				Type: Object,
+				Properties: map[string]pschema.PropertySpec{
+					"name":      {TypeSpec: pschema.TypeSpec{Type: String}, Description: "The name of the " + kind + "."},
+					"namespace": {TypeSpec: pschema.TypeSpec{Type: String}, Description: namespace},
+				},
+				Required: []string{"name"},
+			},
+			Outputs: &pschema.ObjectTypeSpec{
+				Type:       Object,
+				Properties: maps.Clone(resource.Properties),
+				Required:   slices.Clone(resource.Required),
+			},
+			DeprecationMessage: resource.DeprecationMessage,
+			IsOverlay:          true,
+		}
+	}
+}
+
+// describeLookupNamespace returns the description of the namespace parameter
+// of the lookup function of `kind`.
+func describeLookupNamespace(kind string, clusterScoped bool) string {
+	if clusterScoped {
+		return kind + " is cluster-scoped, so leave the namespace unset."
+	}
+	return "The namespace of the " + kind + "."
+}
+
+// lookupFunction is the code of the lookup functions of a language. For every
+// lookup function in the package spec, `template` is written to `file` in the
+// directory of the file of the resource it reads. "{{kind}}" is replaced by
+// the kind, "{{name}}" by its Python name, "{{module}}" by the name of the
+// resource's file without its extension, "{{package}}" by the package or
+// namespace declared in that file, which `packagePattern` matches, and
+// "{{namespace}}" and "{{scope}}" by the description of the namespace
+// parameter and, for cluster-scoped kinds, the same as a Go comment.
+type lookupFunction struct {
+	file           string
+	template       string
+	packagePattern *regexp.Regexp
+}
+
+var lookupFunctionCode = map[string]lookupFunction{
+	NodeJS: {
+		file: "get{{kind}}.ts",
+		template: `// *** WARNING: this file was generated by crd2pulumi. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+import { {{kind}} } from "./{{module}}";
+
+/**
+ * The name and namespace of an existing {{kind}}.
+ */
+export interface Get{{kind}}Args {
+    /**
+     * The name of the {{kind}}.
+     */
+    name: string;
+    /**
+     * {{namespace}}
+     */
+    namespace?: string;
+}
+
+/**
+ * Reads an existing {{kind}} by its name and namespace. The resource is named "<namespace>/<name>" in the program.
+ */
+export function get{{kind}}(args: Get{{kind}}Args, opts?: pulumi.CustomResourceOptions): {{kind}} {
+    const id = args.namespace ? ` + "`${args.namespace}/${args.name}`" + ` : args.name;
+    return {{kind}}.get(id, id, opts);
+}
+`,
+	},
+	Python: {
+		file: "get_{{name}}.py",
+		template: `# coding=utf-8
+# *** WARNING: this file was generated by crd2pulumi. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+from typing import Optional
+
+import pulumi
+
+from .{{module}} import {{kind}}
+
+__all__ = ['get_{{name}}']
+
+
+def get_{{name}}(name: str, namespace: Optional[str] = None, opts: Optional[pulumi.ResourceOptions] = None) -> {{kind}}:
+    """
+    Reads an existing {{kind}} by its name and namespace. The resource is named "<namespace>/<name>" in the program.
+
+    :param str name: The name of the {{kind}}.
+    :param str namespace: {{namespace}}
+    :param pulumi.ResourceOptions opts: Options for the resource.
+    """
+    id = f"{namespace}/{name}" if namespace else name
+    return {{kind}}.get(id, id, opts)
+`,
+	},
+	Go: {
+		file: "get{{kind}}.go",
+		template: `// Code generated by crd2pulumi DO NOT EDIT.
+// *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
+
+package {{package}}
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Lookup{{kind}} reads an existing {{kind}} by its name and namespace. The resource is named "<namespace>/<name>" in
+// the program.{{scope}}
+func Lookup{{kind}}(ctx *pulumi.Context, name, namespace string, opts ...pulumi.ResourceOption) (*{{kind}}, error) {
+	id := name
+	if namespace != "" {
+		id = namespace + "/" + name
+	}
+	return Get{{kind}}(ctx, id, pulumi.ID(id), nil, opts...)
+}
+`,
+		packagePattern: regexp.MustCompile(`(?m)^package (\w+)$`),
+	},
+	DotNet: {
+		file: "Get{{kind}}.cs",
+		template: `// *** WARNING: this file was generated by crd2pulumi. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+namespace {{package}}
+{
+    public static class Get{{kind}}
+    {
+        /// <summary>
+        /// Reads an existing {{kind}} by its name and namespace. The resource is named "&lt;namespace&gt;/&lt;name&gt;" in the program.
+        /// </summary>
+        /// <param name="name">The name of the {{kind}}.</param>
+        /// <param name="namespace">{{namespace}}</param>
+        /// <param name="options">A bag of options that control this resource's behavior.</param>
+        public static {{kind}} Invoke(string name, string? @namespace = null, global::Pulumi.CustomResourceOptions? options = null)
+        {
+            var id = string.IsNullOrEmpty(@namespace) ? name : $"{@namespace}/{name}";
+            return {{kind}}.Get(id, id, options);
+        }
+    }
+}
+`,
+		packagePattern: regexp.MustCompile(`(?m)^namespace ([\w.]+)`),
+	},
+	Java: {
+		file: "Get{{kind}}.java",
+		template: `// *** WARNING: this file was generated by crd2pulumi. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+package {{package}};
+
+public final class Get{{kind}} {
+    private Get{{kind}}() {}
+
+    /**
+     * Reads an existing {{kind}} by its name and namespace. The resource is named "&lt;namespace&gt;/&lt;name&gt;" in the program.
+     *
+     * @param name The name of the {{kind}}.
+     * @param namespace {{namespace}}
+     * @param options Optional settings to control the behavior of the CustomResource.
+     */
+    public static {{kind}} invoke(java.lang.String name, @javax.annotation.Nullable java.lang.String namespace,
+            @javax.annotation.Nullable com.pulumi.resources.CustomResourceOptions options) {
+        var id = namespace == null || namespace.isEmpty() ? name : namespace + "/" + name;
+        return {{kind}}.get(id, com.pulumi.core.Output.of(id), options);
+    }
+}
+`,
+		packagePattern: regexp.MustCompile(`(?m)^package ([\w.]+);`),
+	},
+}
+
+// writeLookupFunctions adds the code of the lookup functions in the package
+// spec to the files generated for `language`, next to the resources they read.
+// The NodeJS functions are also exported by the index of their module, and the
+// Python functions by the __init__ of their module.
+func writeLookupFunctions(pg *PackageGenerator, language string, files map[string][]byte) error {
+	code, ok := lookupFunctionCode[language]
+	if !ok {
+		return fmt.Errorf("lookup functions are not supported for %s", language)
+	}
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		return err
+	}
+
+	clusterScoped := map[string]bool{}
+	for _, crg := range pg.CustomResourceGenerators {
+		for _, v := range crg.Versions {
+			clusterScoped[getToken(crg.Group, v, crg.Kind)] = crg.IsClusterScoped()
+		}
+	}
+
+	for _, token := range slices.Sorted(maps.Keys(pkgSpec.Functions)) {
+		function := pkgSpec.Functions[token]
+		i := strings.LastIndex(token, ":")
+		if !function.IsOverlay || !strings.HasPrefix(token[i+1:], lookupFunctionPrefix) {
+			continue
+		}
+		kind := strings.TrimPrefix(token[i+1:], lookupFunctionPrefix)
+		resourceToken := token[:i+1] + kind
+
+		file, ok := resourceFile(files, kind, resourceToken)
+		if !ok {
+			return fmt.Errorf("cannot add lookup functions: the generated %s code has no %s resource", language, resourceToken)
+		}
+		namespace, scope := describeLookupNamespace(kind, clusterScoped[resourceToken]), ""
+		if clusterScoped[resourceToken] {
+			scope = "\n// " + namespace
+		}
+		var pkg string
+		if code.packagePattern != nil {
+			match := code.packagePattern.FindSubmatch(files[file])
+			if match == nil {
+				return fmt.Errorf("cannot add lookup functions: %s declares no package", file)
+			}
+			pkg = string(match[1])
+		}
+		replacer := strings.NewReplacer(
+			"{{kind}}", kind, "{{name}}", python.PyName(kind), "{{module}}", strings.TrimSuffix(path.Base(file), path.Ext(file)),
+			"{{package}}", pkg, "{{namespace}}", namespace, "{{scope}}", scope,
+		)
+		lookupFile := path.Join(path.Dir(file), replacer.Replace(code.file))
+		files[lookupFile] = []byte(replacer.Replace(code.template))
+
+		switch language {
+		case NodeJS:
+			if err := exportNodeJSLookupFunction(files, lookupFile, kind); err != nil {
+				return err
+			}
+		case Python:
+			initPath := path.Join(path.Dir(file), "__init__.py")
+			init, ok := files[initPath]
+			if !ok {
+				return fmt.Errorf("cannot add lookup functions: %s has no __init__.py", path.Dir(file))
+			}
+			module := strings.TrimSuffix(path.Base(lookupFile), ".py")
+			files[initPath] = []byte(strings.TrimRight(string(init), "\n") + "\nfrom ." + module + " import *\n")
+		}
+	}
+	return nil
+}
+
+// resourceFile returns the file generated for the resource `token` of `kind`: the one named after the kind, in any
+// case and with or without underscores, that contains the token as a string literal. Module indexes also contain the
+// token, but aren't named after the kind.
+func resourceFile(files map[string][]byte, kind, token string) (string, bool) {
+	for _, file := range slices.Sorted(maps.Keys(files)) {
+		name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		if !strings.EqualFold(strings.ReplaceAll(name, "_", ""), kind) {
+			continue
+		}
+		code := string(files[file])
+		if strings.Contains(code, `"`+token+`"`) || strings.Contains(code, `'`+token+`'`) {
+			return file, true
+		}
+	}
+	return "", false
+}
+
+// exportNodeJSLookupFunction exports the lookup function of `kind`, which is in `file`, from the index of its module,
+// lazily loading it like the resources.
+func exportNodeJSLookupFunction(files map[string][]byte, file, kind string) error {
+	indexPath := path.Join(path.Dir(file), "index.ts")
+	index, ok := files[indexPath]
+	if !ok {
+		return fmt.Errorf("cannot add lookup functions: %s has no index.ts", path.Dir(file))
+	}
+	module := "./" + strings.TrimSuffix(path.Base(file), ".ts")
+	exports := fmt.Sprintf(`
+export { Get%[1]sArgs } from "%[2]s";
+export const get%[1]s: typeof import("%[2]s").get%[1]s = null as any;
+utilities.lazyLoad(exports, ["get%[1]s"], () => require("%[2]s"));
+`, kind, module)
+	files[indexPath] = []byte(strings.TrimRight(string(index), "\n") + "\n" + exports)
+	return nil
+}
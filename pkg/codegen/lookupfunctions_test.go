@@ -0,0 +1,198 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"go/format"
+	"reflect"
+	"strings"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestAddLookupFunctions(t *testing.T) {
+	crd := filterTestCRD("stable.example.com", "CronTab", "v1", "v2")
+	crd.Spec.Scope = extensionv1.ClusterScoped
+	spec := pschema.PropertySpec{TypeSpec: pschema.TypeSpec{Type: Object, Ref: "#/types/kubernetes:stable.example.com/v1:CronTabSpec"}}
+	pkgSpec := &pschema.PackageSpec{Resources: map[string]pschema.ResourceSpec{
+		"kubernetes:stable.example.com/v1:CronTab": {ObjectTypeSpec: pschema.ObjectTypeSpec{
+			Properties: map[string]pschema.PropertySpec{"spec": spec},
+			Required:   []string{"spec"},
+		}},
+	}}
+	addLookupFunctions(pkgSpec, crd)
+
+	if len(pkgSpec.Functions) != 1 {
+		t.Fatalf("expected a lookup function for the only generated version, got %v", pkgSpec.Functions)
+	}
+	function, ok := pkgSpec.Functions["kubernetes:stable.example.com/v1:getCronTab"]
+	if !ok {
+		t.Fatalf("expected a getCronTab function, got %v", pkgSpec.Functions)
+	}
+	if !function.IsOverlay {
+		t.Errorf("expected the lookup function to be an overlay")
+	}
+	if want := []string{"name"}; !reflect.DeepEqual(want, function.Inputs.Required) {
+		t.Errorf("expected required inputs %v, got %v", want, function.Inputs.Required)
+	}
+	if want, got := "CronTab is cluster-scoped, so leave the namespace unset.", function.Inputs.Properties["namespace"].Description; got != want {
+		t.Errorf("expected namespace description %q, got %q", want, got)
+	}
+	if !reflect.DeepEqual(spec, function.Outputs.Properties["spec"]) || !reflect.DeepEqual([]string{"spec"}, function.Outputs.Required) {
+		t.Errorf("expected the outputs of the resource, got %v", function.Outputs)
+	}
+}
+
+func TestWriteLookupFunctions(t *testing.T) {
+	functions := map[string]pschema.FunctionSpec{
+		"kubernetes:stable.example.com/v1:getCronTab": {IsOverlay: true},
+		"kubernetes:other.example.com/v1:getCronTab":  {IsOverlay: true},
+	}
+	pg := &PackageGenerator{
+		CustomResourceGenerators: []CustomResourceGenerator{
+			{Group: "stable.example.com", Kind: "CronTab", Versions: []string{"v1"}},
+			{Group: "other.example.com", Kind: "CronTab", Versions: []string{"v1"}, Scope: extensionv1.ClusterScoped},
+		},
+		packageSpec: &pschema.PackageSpec{Functions: functions},
+	}
+
+	tests := []struct {
+		language string
+		files    map[string][]byte
+		want     map[string][]string
+	}{
+		{
+			language: NodeJS,
+			files: map[string][]byte{
+				"stable/v1/cronTab.ts": []byte("    public static readonly __pulumiType = 'kubernetes:stable.example.com/v1:CronTab';\n"),
+				"stable/v1/index.ts":   []byte("            case \"kubernetes:stable.example.com/v1:CronTab\":\n"),
+				"other/v1/cronTab.ts":  []byte("    public static readonly __pulumiType = 'kubernetes:other.example.com/v1:CronTab';\n"),
+				"other/v1/index.ts":    []byte("            case \"kubernetes:other.example.com/v1:CronTab\":\n"),
+			},
+			want: map[string][]string{
+				"stable/v1/getCronTab.ts": {`import { CronTab } from "./cronTab";`, "     * The namespace of the CronTab.\n"},
+				"stable/v1/index.ts": {
+					`export { GetCronTabArgs } from "./getCronTab";`,
+					`utilities.lazyLoad(exports, ["getCronTab"], () => require("./getCronTab"));`,
+				},
+				"other/v1/getCronTab.ts": {"     * CronTab is cluster-scoped, so leave the namespace unset.\n"},
+			},
+		},
+		{
+			language: Python,
+			files: map[string][]byte{
+				"pulumi_crds/stable/v1/CronTab.py":     []byte("            'kubernetes:stable.example.com/v1:CronTab',\n"),
+				"pulumi_crds/stable/v1/__init__.py":    []byte("from .CronTab import *\n"),
+				"pulumi_crds/other/v1/cron_tab.py":     []byte("            'kubernetes:other.example.com/v1:CronTab',\n"),
+				"pulumi_crds/other/v1/__init__.py":     []byte("from .cron_tab import *\n"),
+				"pulumi_crds/stable/v1/CronTabList.py": []byte("            'kubernetes:stable.example.com/v1:CronTabList',\n"),
+			},
+			want: map[string][]string{
+				"pulumi_crds/stable/v1/get_cron_tab.py": {"from .CronTab import CronTab\n", "def get_cron_tab(name: str, namespace: Optional[str] = None"},
+				"pulumi_crds/stable/v1/__init__.py":     {"from .CronTab import *\nfrom .get_cron_tab import *\n"},
+				"pulumi_crds/other/v1/get_cron_tab.py":  {"from .cron_tab import CronTab\n", "CronTab is cluster-scoped"},
+			},
+		},
+		{
+			language: DotNet,
+			files: map[string][]byte{
+				"Stable/V1/CronTab.cs": []byte("namespace Pulumi.Crds.Stable.V1\n{\n    [CrdsResourceType(\"kubernetes:stable.example.com/v1:CronTab\")]\n"),
+				"Other/V1/CronTab.cs":  []byte("namespace Pulumi.Crds.Other.V1\n{\n    [CrdsResourceType(\"kubernetes:other.example.com/v1:CronTab\")]\n"),
+			},
+			want: map[string][]string{
+				"Stable/V1/GetCronTab.cs": {"namespace Pulumi.Crds.Stable.V1\n", "        public static CronTab Invoke(string name, string? @namespace = null"},
+				"Other/V1/GetCronTab.cs":  {"namespace Pulumi.Crds.Other.V1\n"},
+			},
+		},
+		{
+			language: Java,
+			files: map[string][]byte{
+				"src/main/java/com/pulumi/crds/stable/v1/CronTab.java": []byte("package com.pulumi.crds.stable.v1;\n\n" +
+					"@ResourceType(type=\"kubernetes:stable.example.com/v1:CronTab\")\n"),
+				"src/main/java/com/pulumi/crds/other/v1/CronTab.java": []byte("package com.pulumi.crds.other.v1;\n\n" +
+					"@ResourceType(type=\"kubernetes:other.example.com/v1:CronTab\")\n"),
+			},
+			want: map[string][]string{
+				"src/main/java/com/pulumi/crds/stable/v1/GetCronTab.java": {
+					"package com.pulumi.crds.stable.v1;\n",
+					"    public static CronTab invoke(java.lang.String name, @javax.annotation.Nullable java.lang.String namespace,",
+					"        return CronTab.get(id, com.pulumi.core.Output.of(id), options);",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		if err := writeLookupFunctions(pg, tt.language, tt.files); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.language, err)
+			continue
+		}
+		for file, wants := range tt.want {
+			for _, want := range wants {
+				if !strings.Contains(string(tt.files[file]), want) {
+					t.Errorf("%s: expected %s to contain %q, got:\n%s", tt.language, file, want, tt.files[file])
+				}
+			}
+		}
+	}
+}
+
+func TestWriteLookupFunctionsGo(t *testing.T) {
+	pg := &PackageGenerator{
+		CustomResourceGenerators: []CustomResourceGenerator{
+			{Group: "stable.example.com", Kind: "CronTab", Versions: []string{"v1"}, Scope: extensionv1.ClusterScoped},
+		},
+		packageSpec: &pschema.PackageSpec{Functions: map[string]pschema.FunctionSpec{
+			"kubernetes:stable.example.com/v1:getCronTab": {IsOverlay: true},
+		}},
+	}
+	files := map[string][]byte{
+		"stable/v1/cronTab.go": []byte("package v1\n\n\terr := ctx.RegisterResource(\"kubernetes:stable.example.com/v1:CronTab\", name, args, &resource, opts...)\n"),
+		"stable/v1/init.go":    []byte("package v1\n\n\tcase \"kubernetes:stable.example.com/v1:CronTab\":\n"),
+	}
+	if err := writeLookupFunctions(pg, Go, files); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code := files["stable/v1/getCronTab.go"]
+	if !strings.Contains(string(code), "package v1\n") ||
+		!strings.Contains(string(code), "func LookupCronTab(ctx *pulumi.Context, name, namespace string") {
+		t.Errorf("expected a LookupCronTab function in package v1, got:\n%s", code)
+	}
+	if !strings.Contains(string(code), "// CronTab is cluster-scoped, so leave the namespace unset.\n") {
+		t.Errorf("expected LookupCronTab to be documented as cluster-scoped, got:\n%s", code)
+	}
+	formatted, err := format.Source(code)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(formatted) != string(code) {
+		t.Errorf("expected gofmt-formatted code, got:\n%s", code)
+	}
+}
+
+func TestWriteLookupFunctionsMissingResource(t *testing.T) {
+	pg := &PackageGenerator{packageSpec: &pschema.PackageSpec{Functions: map[string]pschema.FunctionSpec{
+		"kubernetes:stable.example.com/v1:getCronTab": {IsOverlay: true},
+	}}}
+	files := map[string][]byte{
+		"stable/v1/init.go":  []byte("package v1\n\n\tcase \"kubernetes:stable.example.com/v1:CronTab\":\n"),
+		"stable/v1/other.go": []byte("package v1\n"),
+	}
+	err := writeLookupFunctions(pg, Go, files)
+	if err == nil || !strings.Contains(err.Error(), "no kubernetes:stable.example.com/v1:CronTab resource") {
+		t.Errorf("expected an error about the missing CronTab resource, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFileName is the name of the manifest written to every output directory, listing the generated files.
+const ManifestFileName = ".crd2pulumi-manifest.json"
+
+// manifest lists the files generated into an output directory, so that the files that are no longer generated can be
+// told apart from hand-written ones.
+type manifest struct {
+	// Files maps the slash-separated path of every generated file, relative to the output directory, to the hash of
+	// its contents.
+	Files map[string]string `json:"files"`
+}
+
+// newManifest returns the manifest of `files`, also listing the `kept` files of the previous manifest.
+func newManifest(files map[string]*bytes.Buffer, kept map[string]string) manifest {
+	m := manifest{Files: make(map[string]string, len(files)+len(kept))}
+	for path, hash := range kept {
+		m.Files[path] = hash
+	}
+	for path, code := range files {
+		m.Files[filepath.ToSlash(path)] = hashFile(code.Bytes())
+	}
+	return m
+}
+
+// addManifest adds the manifest of `files` to them. The `kept` files of the previous manifest, stale files that weren't
+// pruned, stay listed with their hashes, so that a later run with --prune still deletes them.
+func addManifest(files map[string]*bytes.Buffer, kept map[string]string) error {
+	// encoding/json sorts the keys of maps, so the manifest is deterministic.
+	data, err := json.MarshalIndent(newManifest(files, kept), "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not write manifest: %w", err)
+	}
+	files[ManifestFileName] = bytes.NewBuffer(append(data, '\n'))
+	return nil
+}
+
+// readManifest reads the manifest of `outputDir`. An empty manifest is returned if there is none, e.g. because the
+// directory was generated by an older version of crd2pulumi.
+func readManifest(outputDir string) (manifest, error) {
+	path := filepath.Join(outputDir, ManifestFileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest{}, nil
+	}
+	if err != nil {
+		return manifest{}, fmt.Errorf("could not read manifest %s: %w", path, err)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return manifest{}, fmt.Errorf("could not read manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// hashFile returns the hash of a file's contents, as recorded in the manifest.
+func hashFile(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// staleFiles returns the slash-separated paths of the files in `previous` that are no longer in `files`, sorted.
+// Files that were edited since they were generated, or already deleted, are left out, so that pruning never deletes
+// changes made by hand.
+func staleFiles(previous manifest, files map[string]*bytes.Buffer, outputDir string) ([]string, error) {
+	generated := make(map[string]bool, len(files))
+	for path := range files {
+		generated[filepath.ToSlash(path)] = true
+	}
+
+	var stale []string
+	for path, hash := range previous.Files {
+		if generated[path] || path == ManifestFileName {
+			continue
+		}
+		outputFilePath := filepath.Join(outputDir, filepath.FromSlash(path))
+		data, err := os.ReadFile(outputFilePath)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read file %s: %w", outputFilePath, err)
+		}
+		if hashFile(data) == hash {
+			stale = append(stale, path)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// pruneFiles deletes the `stale` files from `outputDir`, along with any directories left empty.
+func pruneFiles(stale []string, outputDir string) error {
+	for _, path := range stale {
+		outputFilePath := filepath.Join(outputDir, filepath.FromSlash(path))
+		if err := os.Remove(outputFilePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("could not delete file %s: %w", outputFilePath, err)
+		}
+		for dir := filepath.Dir(filepath.FromSlash(path)); dir != "."; dir = filepath.Dir(dir) {
+			// Remove fails on directories that aren't empty, which is where we stop.
+			if err := os.Remove(filepath.Join(outputDir, dir)); err != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
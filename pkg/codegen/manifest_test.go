@@ -0,0 +1,191 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTestFiles writes each file to its path relative to `dir`.
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, contents := range files {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// testManifest returns the contents of the manifest of `files`.
+func testManifest(t *testing.T, files map[string]string) string {
+	t.Helper()
+	buffers := map[string]*bytes.Buffer{}
+	for path, contents := range files {
+		buffers[path] = bytes.NewBufferString(contents)
+	}
+	if err := addManifest(buffers, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buffers[ManifestFileName].String()
+}
+
+func TestAddManifest(t *testing.T) {
+	files := map[string]*bytes.Buffer{
+		"b.txt":   bytes.NewBufferString("b\n"),
+		"a/a.txt": bytes.NewBufferString("a\n"),
+	}
+	if err := addManifest(files, map[string]string{"c.txt": "sha256:c"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{
+  "files": {
+    "a/a.txt": "sha256:87428fc522803d31065e7bce3cf03fe475096631e5e07bbd7a0fde60c4cf25c7",
+    "b.txt": "sha256:0263829989b6fd954f72baaf2fc64bc2e2f01d692d4de72986ea808f6e99813f",
+    "c.txt": "sha256:c"
+  }
+}
+`
+	if got := files[ManifestFileName].String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	outputDir := t.TempDir()
+	m, err := readManifest(outputDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.Files) != 0 {
+		t.Errorf("expected an empty manifest, got %v", m)
+	}
+
+	writeTestFiles(t, outputDir, map[string]string{ManifestFileName: testManifest(t, map[string]string{"a.txt": "a\n"})})
+	m, err = readManifest(outputDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"a.txt": hashFile([]byte("a\n"))}; !reflect.DeepEqual(want, m.Files) {
+		t.Errorf("expected %v, got %v", want, m.Files)
+	}
+
+	writeTestFiles(t, outputDir, map[string]string{ManifestFileName: "not json"})
+	if _, err := readManifest(outputDir); err == nil {
+		t.Error("expected an error for an invalid manifest")
+	}
+}
+
+func TestPruneFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	writeTestFiles(t, outputDir, map[string]string{
+		"kept.txt":           "kept\n",
+		"stale/v1/stale.txt": "stale\n",
+		"edited.txt":         "edited by hand\n",
+		"handwritten.txt":    "mine\n",
+		ManifestFileName: testManifest(t, map[string]string{
+			"kept.txt":           "kept\n",
+			"stale/v1/stale.txt": "stale\n",
+			"edited.txt":         "generated\n",
+			"deleted.txt":        "deleted by hand\n",
+		}),
+	})
+	files := map[string]*bytes.Buffer{
+		"kept.txt": bytes.NewBufferString("kept\n"),
+	}
+
+	previous, err := readManifest(outputDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stale, err := staleFiles(previous, files, outputDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"stale/v1/stale.txt"}; !reflect.DeepEqual(want, stale) {
+		t.Fatalf("expected stale files %v, got %v", want, stale)
+	}
+
+	if err := pruneFiles(stale, outputDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, path := range []string{"stale/v1/stale.txt", "stale"} {
+		if _, err := os.Stat(filepath.Join(outputDir, path)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be pruned", path)
+		}
+	}
+	for _, path := range []string{"kept.txt", "edited.txt", "handwritten.txt"} {
+		if _, err := os.Stat(filepath.Join(outputDir, path)); err != nil {
+			t.Errorf("expected %s to be kept: %v", path, err)
+		}
+	}
+}
+
+func TestGeneratePackagePrune(t *testing.T) {
+	generated := map[string]string{"kept.txt": "kept\n", "stale.txt": "stale\n"}
+	codeGenFuncs["test"] = func(*PackageGenerator, *CodegenSettings) (map[string]*bytes.Buffer, error) {
+		files := map[string]*bytes.Buffer{}
+		for path, contents := range generated {
+			files[path] = bytes.NewBufferString(contents)
+		}
+		return files, nil
+	}
+	t.Cleanup(func() { delete(codeGenFuncs, "test") })
+
+	outputDir := filepath.Join(t.TempDir(), "test")
+	cs := &CodegenSettings{Language: "test", OutputDir: outputDir, Overwrite: true}
+	if err := generatePackage(&PackageGenerator{}, cs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without --prune, the file that is no longer generated is kept, and stays in the manifest.
+	delete(generated, "stale.txt")
+	if err := generatePackage(&PackageGenerator{}, cs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "stale.txt")); err != nil {
+		t.Errorf("expected stale.txt to be kept without --prune: %v", err)
+	}
+	m, err := readManifest(outputDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := m.Files["stale.txt"]; !ok {
+		t.Errorf("expected stale.txt to stay in the manifest, got %v", m.Files)
+	}
+
+	// With --prune, it is deleted and dropped from the manifest.
+	cs.Prune = true
+	if err := generatePackage(&PackageGenerator{}, cs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "stale.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected stale.txt to be pruned, got %v", err)
+	}
+	m, err = readManifest(outputDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"kept.txt": hashFile([]byte("kept\n"))}; !reflect.DeepEqual(want, m.Files) {
+		t.Errorf("expected %v, got %v", want, m.Files)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/pulumi/crd2pulumi/internal/versions"
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+var moduleNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// languageModuleKeys maps each language to the key of its language info in
+// the package spec that maps modules to language-specific packages. Java is
+// missing since GenerateJava replaces its language info entirely.
+var languageModuleKeys = map[string]string{
+	"csharp": "namespaces",
+	"go":     "moduleToPackage",
+	"nodejs": "moduleToPackage",
+	"python": "moduleNameOverrides",
+}
+
+// WithGroupModules overrides the module each API group is generated into,
+// e.g. {"networking.gke.io": "gke"}. By default the module is the first word
+// of the group.
+func WithGroupModules(modules map[string]string) PackageOption {
+	return func(opts *packageOptions) {
+		opts.groupModules = modules
+	}
+}
+
+// validateGroupModules returns an error if any of the overridden module names
+// cannot be used as a package name in every language.
+func validateGroupModules(modules map[string]string) error {
+	for group, module := range modules {
+		if !moduleNameRegex.MatchString(module) {
+			return fmt.Errorf("invalid module %q for group %q: must be alphanumeric and start with a letter", module, group)
+		}
+	}
+	return nil
+}
+
+// groupModules returns the module of every group in `groups`. Modules default
+// to the first word of the group, unless overridden. Groups whose default
+// modules collide are disambiguated by adding words of the group until they
+// are unique, so networking.istio.io and networking.gke.io are generated into
+// networkingistio and networkinggke. Collisions that cannot be resolved this
+// way, such as two groups overridden to the same module, are an error.
+func groupModules(groups []string, overrides map[string]string) (map[string]string, error) {
+	sorted := append([]string(nil), groups...)
+	sort.Strings(sorted)
+
+	modules := make(map[string]string, len(sorted))
+	for _, group := range sorted {
+		if module, ok := overrides[group]; ok {
+			modules[group] = module
+			continue
+		}
+		module, err := versions.GroupPrefix(group)
+		if err != nil {
+			return nil, err
+		}
+		modules[group] = module
+	}
+
+	for words := 2; ; words++ {
+		changed := false
+		for _, group := range collidingGroups(sorted, modules) {
+			if _, ok := overrides[group]; ok {
+				continue
+			}
+			module, err := versions.GroupLabels(group, words)
+			if err != nil {
+				return nil, err
+			}
+			if module != modules[group] {
+				modules[group] = module
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	owners := map[string]string{}
+	for _, group := range sorted {
+		module := modules[group]
+		if owner, ok := owners[module]; ok {
+			return nil, fmt.Errorf("groups %q and %q are both generated into module %q; "+
+				"set a different module for one of them with --group-module", owner, group, module)
+		}
+		owners[module] = group
+	}
+	return modules, nil
+}
+
+// collidingGroups returns the groups whose module is shared with another group.
+func collidingGroups(groups []string, modules map[string]string) []string {
+	counts := map[string]int{}
+	for _, group := range groups {
+		counts[modules[group]]++
+	}
+	var colliding []string
+	for _, group := range groups {
+		if counts[modules[group]] > 1 {
+			colliding = append(colliding, group)
+		}
+	}
+	return colliding
+}
+
+// module returns the module of `group`.
+func (pg *PackageGenerator) module(group string) (string, error) {
+	if module, ok := pg.groupModules[group]; ok {
+		return module, nil
+	}
+	return versions.GroupPrefix(group)
+}
+
+// languageModules returns the language-specific package of every
+// <group>/<version> module, e.g. "Certmanager.V1" for C# or "certmanager.v1"
+// for Java. Every other language uses "certmanager/v1".
+func (pg *PackageGenerator) languageModules(lang string) (map[string]string, error) {
+	modules := map[string]string{}
+	for _, groupVersion := range pg.GroupVersions {
+		group, version, err := versions.SplitGroupVersion(groupVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version: %w", err)
+		}
+		module, err := pg.module(group)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version: %w", err)
+		}
+		switch lang {
+		case "csharp":
+			modules[groupVersion] = cases.Title(language.Und).String(module) + "." + versions.VersionToUpper(version)
+		case "java":
+			modules[groupVersion] = module + "." + version
+		default:
+			modules[groupVersion] = module + "/" + version
+		}
+	}
+
+	switch lang {
+	case "csharp":
+		modules["meta/v1"] = "Meta.V1"
+	case "java":
+		modules["meta/v1"] = "meta.v1"
+	default:
+		modules["meta/v1"] = "meta/v1"
+	}
+	return modules, nil
+}
+
+// setLanguageModules points the module mappings of every language in the
+// package spec at the group modules, replacing the defaults set by
+// gen.PulumiSchema, which only use the first word of each group.
+func (pg *PackageGenerator) setLanguageModules(pkgSpec *pschema.PackageSpec) error {
+	if pkgSpec.Language == nil {
+		pkgSpec.Language = map[string]pschema.RawMessage{}
+	}
+	for lang, key := range languageModuleKeys {
+		modules, err := pg.languageModules(lang)
+		if err != nil {
+			return err
+		}
+
+		info := map[string]any{}
+		if raw, ok := pkgSpec.Language[lang]; ok {
+			if err := json.Unmarshal(raw, &info); err != nil {
+				return fmt.Errorf("could not read %s language info: %w", lang, err)
+			}
+		}
+		mapping, _ := info[key].(map[string]any)
+		if mapping == nil {
+			mapping = map[string]any{}
+		}
+		for module, pkg := range modules {
+			mapping[module] = pkg
+		}
+		info[key] = mapping
+
+		data, err := json.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("could not write %s language info: %w", lang, err)
+		}
+		pkgSpec.Language[lang] = pschema.RawMessage(data)
+	}
+	return nil
+}
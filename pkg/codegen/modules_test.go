@@ -0,0 +1,195 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func TestGroupModules(t *testing.T) {
+	tests := []struct {
+		name      string
+		groups    []string
+		overrides map[string]string
+		want      map[string]string
+		wantErr   string
+	}{
+		{
+			name:   "No collisions",
+			groups: []string{"cert-manager.io", "acme.cert-manager.io"},
+			want:   map[string]string{"cert-manager.io": "certmanager", "acme.cert-manager.io": "acme"},
+		},
+		{
+			name:   "Disambiguated",
+			groups: []string{"networking.istio.io", "networking.gke.io", "security.istio.io"},
+			want: map[string]string{
+				"networking.istio.io": "networkingistio",
+				"networking.gke.io":   "networkinggke",
+				"security.istio.io":   "security",
+			},
+		},
+		{
+			name:   "Disambiguated with every word",
+			groups: []string{"a.b.example.com", "a.b.example.org"},
+			want:   map[string]string{"a.b.example.com": "abexamplecom", "a.b.example.org": "abexampleorg"},
+		},
+		{
+			name:      "Override",
+			groups:    []string{"networking.istio.io", "networking.gke.io"},
+			overrides: map[string]string{"networking.gke.io": "gke"},
+			want:      map[string]string{"networking.istio.io": "networking", "networking.gke.io": "gke"},
+		},
+		{
+			name:      "Override collides with default",
+			groups:    []string{"networking.istio.io", "istio.example.com"},
+			overrides: map[string]string{"istio.example.com": "networking"},
+			want:      map[string]string{"networking.istio.io": "networkingistio", "istio.example.com": "networking"},
+		},
+		{
+			name:      "Overrides collide",
+			groups:    []string{"networking.istio.io", "networking.gke.io"},
+			overrides: map[string]string{"networking.istio.io": "net", "networking.gke.io": "net"},
+			wantErr:   `groups "networking.gke.io" and "networking.istio.io" are both generated into module "net"`,
+		},
+		{
+			name:    "Indistinguishable",
+			groups:  []string{"a-b.io", "ab.io"},
+			wantErr: `groups "a-b.io" and "ab.io" are both generated into module "abio"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := groupModules(tt.groups, tt.overrides)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateGroupModules(t *testing.T) {
+	if err := validateGroupModules(map[string]string{"networking.gke.io": "gke2"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, module := range []string{"", "gke-networking", "2gke"} {
+		if err := validateGroupModules(map[string]string{"networking.gke.io": module}); err == nil {
+			t.Errorf("expected an error for module %q", module)
+		}
+	}
+}
+
+func TestLanguageModules(t *testing.T) {
+	pg := &PackageGenerator{
+		GroupVersions: []string{"networking.istio.io/v1beta1", "networking.gke.io/v1"},
+		groupModules:  map[string]string{"networking.istio.io": "networkingistio", "networking.gke.io": "gke"},
+	}
+
+	tests := []struct {
+		lang string
+		want map[string]string
+	}{
+		{
+			lang: "csharp",
+			want: map[string]string{
+				"networking.istio.io/v1beta1": "Networkingistio.V1Beta1",
+				"networking.gke.io/v1":        "Gke.V1",
+				"meta/v1":                     "Meta.V1",
+			},
+		},
+		{
+			lang: "java",
+			want: map[string]string{
+				"networking.istio.io/v1beta1": "networkingistio.v1beta1",
+				"networking.gke.io/v1":        "gke.v1",
+				"meta/v1":                     "meta.v1",
+			},
+		},
+		{
+			lang: "go",
+			want: map[string]string{
+				"networking.istio.io/v1beta1": "networkingistio/v1beta1",
+				"networking.gke.io/v1":        "gke/v1",
+				"meta/v1":                     "meta/v1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			got, err := pg.languageModules(tt.lang)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetLanguageModules(t *testing.T) {
+	pg := &PackageGenerator{
+		GroupVersions: []string{"networking.istio.io/v1", "networking.gke.io/v1"},
+		groupModules:  map[string]string{"networking.istio.io": "networkingistio", "networking.gke.io": "networkinggke"},
+	}
+	pkgSpec := &pschema.PackageSpec{Language: map[string]pschema.RawMessage{
+		"nodejs": pschema.RawMessage(`{"moduleToPackage":{"networking.istio.io/v1":"networking/v1","apps/v1":"apps/v1"},"respectSchemaVersion":true}`),
+	}}
+	if err := pg.setLanguageModules(pkgSpec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var nodejs map[string]any
+	if err := json.Unmarshal(pkgSpec.Language["nodejs"], &nodejs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"moduleToPackage": map[string]any{
+			"networking.istio.io/v1": "networkingistio/v1",
+			"networking.gke.io/v1":   "networkinggke/v1",
+			"meta/v1":                "meta/v1",
+			"apps/v1":                "apps/v1",
+		},
+		"respectSchemaVersion": true,
+	}
+	if !reflect.DeepEqual(want, nodejs) {
+		t.Errorf("expected nodejs language info %v, got %v", want, nodejs)
+	}
+
+	for lang, key := range languageModuleKeys {
+		var info map[string]any
+		if err := json.Unmarshal(pkgSpec.Language[lang], &info); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if modules, _ := info[key].(map[string]any); modules["networking.gke.io/v1"] == nil {
+			t.Errorf("expected %s.%s to map networking.gke.io/v1", lang, key)
+		}
+	}
+}
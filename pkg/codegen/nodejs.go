@@ -62,6 +62,20 @@ function unusedGetVersion(): string {`, KubernetesProviderVersion)))
 		files[nodejsMetaPath] = append(code, []byte("\n"+nodejsMetaFile)...)
 	}
 
+	if err := checkClusterScopedNamespaces(pg, NodeJS, files); err != nil {
+		return nil, err
+	}
+	if cs.ValidationHelpers {
+		if err := addValidationHelpers(pg, NodeJS, "", files); err != nil {
+			return nil, err
+		}
+	}
+	if pg.lookupFunctions {
+		if err := writeLookupFunctions(pg, NodeJS, files); err != nil {
+			return nil, err
+		}
+	}
+
 	buffers := map[string]*bytes.Buffer{}
 	for name, code := range files {
 		buffers[name] = bytes.NewBuffer(code)
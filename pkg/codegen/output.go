@@ -0,0 +1,162 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// writeOutputDir writes `files` to `outputDir` and deletes the `stale` files from it, without ever leaving it
+// half-updated. The new contents of the directory are staged in a temporary sibling directory, into which the entries
+// of `outputDir` that aren't generated, such as hand-written files and node_modules, are moved, and the staged
+// directory is then swapped into place. If anything fails, the moved entries are moved back, the staged directory is
+// removed and `outputDir` is left as it was.
+func writeOutputDir(files map[string]*bytes.Buffer, outputDir string, stale []string) (err error) {
+	outputDir, err = filepath.Abs(outputDir)
+	if err != nil {
+		return fmt.Errorf("could not resolve output directory: %w", err)
+	}
+	// Swap the directory a symlinked output directory points to, rather than the symlink.
+	if resolved, err := filepath.EvalSymlinks(outputDir); err == nil {
+		outputDir = resolved
+	}
+	// The staging directory is created in the parent, which must not be inside the output directory.
+	parent, name := filepath.Dir(outputDir), filepath.Base(outputDir)
+	if parent == outputDir {
+		return fmt.Errorf("cannot generate into the root directory %s", outputDir)
+	}
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return fmt.Errorf("could not create directory %s: %w", parent, err)
+	}
+	mode := fs.FileMode(0755)
+	if info, err := os.Stat(outputDir); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	// Staging next to the output directory keeps it on the same file system, so entries can be moved into it and it
+	// can be renamed into place.
+	staging, err := os.MkdirTemp(parent, "."+name+".tmp-")
+	if err != nil {
+		return fmt.Errorf("could not create staging directory: %w", err)
+	}
+	var moved []string
+	defer func() {
+		if err != nil {
+			for i := len(moved) - 1; i >= 0; i-- {
+				os.Rename(filepath.Join(staging, moved[i]), filepath.Join(outputDir, moved[i]))
+			}
+			os.RemoveAll(staging)
+		}
+	}()
+	if err := os.Chmod(staging, mode); err != nil {
+		return fmt.Errorf("could not create staging directory: %w", err)
+	}
+
+	replaced, dirs := map[string]bool{}, map[string]bool{}
+	for _, path := range append(slices.Collect(maps.Keys(files)), stale...) {
+		path = filepath.Clean(filepath.FromSlash(path))
+		replaced[path] = true
+		for dir := filepath.Dir(path); dir != "."; dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+	if err := stageDir(outputDir, staging, ".", replaced, dirs, &moved); err != nil {
+		return err
+	}
+	if err := pruneFiles(stale, staging); err != nil {
+		return err
+	}
+	if err := writeFiles(files, staging); err != nil {
+		return err
+	}
+	return swapDir(staging, outputDir)
+}
+
+// stageDir moves the entries of the directory `rel` of `dir` to the same path in `staging`, appending their paths to
+// `moved`. The `replaced` files, which are generated or pruned, are left behind, and the `dirs` that contain them are
+// recreated in `staging` and staged in turn. A missing `dir` is staged as an empty directory.
+func stageDir(dir, staging, rel string, replaced, dirs map[string]bool, moved *[]string) error {
+	entries, err := os.ReadDir(filepath.Join(dir, rel))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read directory %s: %w", filepath.Join(dir, rel), err)
+	}
+	for _, entry := range entries {
+		path := filepath.Join(rel, entry.Name())
+		switch {
+		case dirs[path]:
+			// Generated files are written into real directories, rather than through symlinks.
+			if !entry.IsDir() {
+				return fmt.Errorf("could not write to %s: not a directory", filepath.Join(dir, path))
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if err := os.Mkdir(filepath.Join(staging, path), info.Mode().Perm()); err != nil {
+				return fmt.Errorf("could not create directory %s: %w", filepath.Join(staging, path), err)
+			}
+			if err := stageDir(dir, staging, path, replaced, dirs, moved); err != nil {
+				return err
+			}
+		case replaced[path]:
+			continue
+		default:
+			if err := os.Rename(filepath.Join(dir, path), filepath.Join(staging, path)); err != nil {
+				return fmt.Errorf("could not move %s: %w", filepath.Join(dir, path), err)
+			}
+			*moved = append(*moved, path)
+		}
+	}
+	return nil
+}
+
+// swapDir replaces `dir` with `staging`. The existing `dir` is moved aside first and moved back if `staging` can't be
+// moved into its place, so `dir` is only ever missing for an instant.
+func swapDir(staging, dir string) error {
+	backup := ""
+	if _, err := os.Lstat(dir); err == nil {
+		backup = staging + ".old"
+		if err := os.Rename(dir, backup); err != nil {
+			return fmt.Errorf("could not move %s aside: %w", dir, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Rename(staging, dir); err != nil {
+		if backup != "" {
+			if rollbackErr := os.Rename(backup, dir); rollbackErr != nil {
+				return fmt.Errorf("could not move %s into place: %w; the previous contents are in %s", staging, err, backup)
+			}
+		}
+		return fmt.Errorf("could not move %s into place: %w", staging, err)
+	}
+
+	if backup != "" {
+		// The new contents are in place, so failing to clean up the old ones isn't an error.
+		os.RemoveAll(backup)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readTestFiles returns the contents of every file in `dir`, by slash-separated path.
+func readTestFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestWriteOutputDir(t *testing.T) {
+	parent := t.TempDir()
+	outputDir := filepath.Join(parent, "nodejs")
+	writeTestFiles(t, outputDir, map[string]string{
+		"changed.txt":        "old\n",
+		"handwritten.txt":    "mine\n",
+		"stale/v1/stale.txt": "stale\n",
+	})
+
+	err := writeOutputDir(map[string]*bytes.Buffer{
+		"changed.txt":   bytes.NewBufferString("new\n"),
+		"new/added.txt": bytes.NewBufferString("added\n"),
+	}, outputDir, []string{"stale/v1/stale.txt"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"changed.txt":     "new\n",
+		"handwritten.txt": "mine\n",
+		"new/added.txt":   "added\n",
+	}
+	if got := readTestFiles(t, outputDir); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "stale")); !os.IsNotExist(err) {
+		t.Errorf("expected the stale directory to be pruned")
+	}
+	if entries, _ := os.ReadDir(parent); len(entries) != 1 {
+		t.Errorf("expected only the output directory to be left, got %v", entries)
+	}
+}
+
+func TestWriteOutputDirNew(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "crds", "nodejs")
+	err := writeOutputDir(map[string]*bytes.Buffer{
+		"index.ts": bytes.NewBufferString("export {}\n"),
+	}, outputDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want, got := map[string]string{"index.ts": "export {}\n"}, readTestFiles(t, outputDir); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestWriteOutputDirRollback(t *testing.T) {
+	parent := t.TempDir()
+	outputDir := filepath.Join(parent, "nodejs")
+	existing := map[string]string{
+		"a/handwritten.ts": "mine\n",
+		"index.ts":         "old\n",
+		"file.txt":         "a file, not a directory\n",
+	}
+	writeTestFiles(t, outputDir, existing)
+
+	err := writeOutputDir(map[string]*bytes.Buffer{
+		"index.ts":        bytes.NewBufferString("new\n"),
+		"file.txt/nested": bytes.NewBufferString("can't be written\n"),
+	}, outputDir, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if got := readTestFiles(t, outputDir); !reflect.DeepEqual(existing, got) {
+		t.Errorf("expected the output directory to be unchanged, got %v", got)
+	}
+	if entries, _ := os.ReadDir(parent); len(entries) != 1 {
+		t.Errorf("expected the staging directory to be removed, got %v", entries)
+	}
+}
+
+func TestWriteOutputDirKeepsEntries(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "nodejs")
+	writeTestFiles(t, outputDir, map[string]string{
+		"node_modules/dep/index.js": "dep\n",
+		"types/v1/handwritten.ts":   "mine\n",
+	})
+	if err := os.Chmod(outputDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(filepath.Join(outputDir, "node_modules", "dep", "index.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writeOutputDir(map[string]*bytes.Buffer{
+		"types/v1/crontab.ts": bytes.NewBufferString("generated\n"),
+	}, outputDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"node_modules/dep/index.js": "dep\n",
+		"types/v1/handwritten.ts":   "mine\n",
+		"types/v1/crontab.ts":       "generated\n",
+	}
+	if got := readTestFiles(t, outputDir); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	// Entries without generated files are moved, not copied.
+	after, err := os.Stat(filepath.Join(outputDir, "node_modules", "dep", "index.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(before, after) {
+		t.Error("expected node_modules to be moved rather than copied")
+	}
+	if info, err := os.Stat(outputDir); err != nil || info.Mode().Perm() != 0o700 {
+		t.Errorf("expected the output directory to keep mode 0700, got %v (%v)", info.Mode().Perm(), err)
+	}
+}
+
+func TestWriteOutputDirRelative(t *testing.T) {
+	outputDir := t.TempDir()
+	t.Chdir(outputDir)
+	writeTestFiles(t, outputDir, map[string]string{"handwritten.txt": "mine\n"})
+
+	err := writeOutputDir(map[string]*bytes.Buffer{
+		"index.ts": bytes.NewBufferString("export {}\n"),
+	}, ".", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"handwritten.txt": "mine\n", "index.ts": "export {}\n"}
+	if got := readTestFiles(t, outputDir); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestWriteOutputDirRoot(t *testing.T) {
+	root := filepath.VolumeName(os.TempDir()) + string(filepath.Separator)
+	if err := writeOutputDir(map[string]*bytes.Buffer{}, root, nil); err == nil {
+		t.Error("expected an error for the root directory")
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pulumi/crd2pulumi/internal/unstruct"
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// DefaultOutputOnly are the resource properties that are output-only unless
+// overridden with WithOutputOnly.
+var DefaultOutputOnly = []string{"status"}
+
+// WithOutputOnly makes the given top-level resource properties output-only, so
+// they can be read from the generated resources but not set. Each field is
+// either a property name such as "status", which applies to every kind, or a
+// property of a single kind such as "Certificate.secretRef". Properties marked
+// `readOnly: true` in the CRD schema are always output-only. Defaults to
+// DefaultOutputOnly.
+func WithOutputOnly(fields []string) PackageOption {
+	return func(opts *packageOptions) {
+		opts.outputOnly = fields
+	}
+}
+
+// validateOutputOnly returns an error if any of the output-only fields is not
+// a top-level property name, optionally qualified by a kind.
+func validateOutputOnly(fields []string) error {
+	for _, field := range fields {
+		kind, name, qualified := strings.Cut(field, ".")
+		if !qualified {
+			kind, name = "", field
+		}
+		if name == "" || strings.Contains(name, ".") || qualified && (kind == "" || !unicode.IsUpper(rune(kind[0]))) {
+			return fmt.Errorf("invalid output-only field %q: must be a top-level property such as status, "+
+				"optionally qualified by a kind such as Certificate.status", field)
+		}
+	}
+	return nil
+}
+
+// outputOnlyFields returns the output-only fields in `fields` that apply to `kind`.
+func outputOnlyFields(kind string, fields []string) []string {
+	var names []string
+	for _, field := range fields {
+		if k, name, qualified := strings.Cut(field, "."); !qualified {
+			names = append(names, field)
+		} else if k == kind {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// readOnlyProperties returns the top-level properties marked `readOnly: true`
+// in the schemas of the CRDs in `yamlData`, by CRD name and version, and the
+// JSON paths of the nested properties marked `readOnly: true` below the other
+// top-level properties. The typed CRD schema has no readOnly field, so the
+// documents are read again.
+func readOnlyProperties(yamlData [][]byte) (readOnly, nested map[string]map[string][]string, err error) {
+	readOnly = map[string]map[string][]string{}
+	nested = map[string]map[string][]string{}
+	for _, data := range yamlData {
+		dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 128)
+		for {
+			var document map[string]any
+			if err := dec.Decode(&document); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal yaml: %w", err)
+			}
+			if kind, _, _ := unstructured.NestedString(document, "kind"); kind != unstruct.CRD {
+				continue
+			}
+
+			name, _, _ := unstructured.NestedString(document, "metadata", "name")
+			versions, _, _ := unstructured.NestedSlice(document, "spec", "versions")
+			for _, v := range versions {
+				version, ok := v.(map[string]any)
+				if !ok {
+					continue
+				}
+				versionName, _, _ := unstructured.NestedString(version, "name")
+				properties, _, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema", "properties")
+				for property, propertySchema := range properties {
+					if isReadOnly, _, _ := unstructured.NestedBool(properties, property, "readOnly"); isReadOnly {
+						if readOnly[name] == nil {
+							readOnly[name] = map[string][]string{}
+						}
+						readOnly[name][versionName] = append(readOnly[name][versionName], property)
+						continue
+					}
+					if property == "metadata" {
+						continue
+					}
+					if paths := nestedReadOnlyPaths(propertySchema, "."+property); len(paths) > 0 {
+						if nested[name] == nil {
+							nested[name] = map[string][]string{}
+						}
+						nested[name][versionName] = append(nested[name][versionName], paths...)
+					}
+				}
+				slices.Sort(readOnly[name][versionName])
+				if nested[name] != nil {
+					slices.Sort(nested[name][versionName])
+				}
+			}
+		}
+	}
+	return readOnly, nested, nil
+}
+
+// nestedReadOnlyPaths returns the JSON paths of the properties marked
+// `readOnly: true` in `schema`, the schema of the field at `path`. The
+// properties of a read-only property aren't listed.
+func nestedReadOnlyPaths(schema any, path string) []string {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+	var paths []string
+	properties, _ := s["properties"].(map[string]any)
+	for name, propertySchema := range properties {
+		propertyPath := path + "." + name
+		if isReadOnly, _, _ := unstructured.NestedBool(properties, name, "readOnly"); isReadOnly {
+			paths = append(paths, propertyPath)
+			continue
+		}
+		paths = append(paths, nestedReadOnlyPaths(propertySchema, propertyPath)...)
+	}
+	paths = append(paths, nestedReadOnlyPaths(s["items"], path+"[*]")...)
+	paths = append(paths, nestedReadOnlyPaths(s["additionalProperties"], path+".*")...)
+	return paths
+}
+
+// readOnlyDiagnostics reports the nested read-only properties of the versions
+// of `crd` in `nested` that can still be set, because input and output types
+// share nested object types. The properties of output-only top-level
+// properties, such as status, can't be set, so they aren't reported.
+func readOnlyDiagnostics(crd extensionv1.CustomResourceDefinition, nested map[string][]string, outputOnly []string) []Diagnostic {
+	fields := outputOnlyFields(crd.Spec.Names.Kind, outputOnly)
+	var diagnostics []Diagnostic
+	for _, v := range crd.Spec.Versions {
+		for _, path := range nested[v.Name] {
+			property, _, _ := strings.Cut(strings.TrimPrefix(path, "."), ".")
+			property, _, _ = strings.Cut(property, "[")
+			if slices.Contains(fields, property) {
+				continue
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				CRD:     crd.Name,
+				Version: v.Name,
+				Path:    path,
+				Reason:  "readOnly, but only top-level properties can be output-only, so it can still be set",
+			})
+		}
+	}
+	return diagnostics
+}
+
+// setOutputOnly removes the output-only properties of every resource from its
+// input properties. They are kept in its output properties, so they can still
+// be read.
+func (pg *PackageGenerator) setOutputOnly(pkgSpec *pschema.PackageSpec) {
+	for _, crg := range pg.CustomResourceGenerators {
+		kindFields := outputOnlyFields(crg.Kind, pg.outputOnly)
+		for _, version := range crg.Versions {
+			fields := append(slices.Clone(kindFields), crg.readOnly[version]...)
+			token := getToken(crg.Group, version, crg.Kind)
+			for _, t := range []string{token, token + "Patch"} {
+				resource, ok := pkgSpec.Resources[t]
+				if !ok {
+					continue
+				}
+				for _, field := range fields {
+					delete(resource.InputProperties, field)
+				}
+				resource.RequiredInputs = slices.DeleteFunc(slices.Clone(resource.RequiredInputs), func(input string) bool {
+					return slices.Contains(fields, input)
+				})
+				pkgSpec.Resources[t] = resource
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+const readOnlyCRD = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-crd
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+spec:
+  group: stable.example.com
+  names:
+    kind: CronTab
+    plural: crontabs
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              image:
+                type: string
+                readOnly: true
+              ports:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    hostPort:
+                      type: integer
+                      readOnly: true
+          status:
+            type: object
+            properties:
+              active:
+                type: boolean
+                readOnly: true
+          lastScheduleTime:
+            type: string
+            readOnly: true
+          observedGeneration:
+            type: integer
+            readOnly: true
+  - name: v1beta1
+    served: true
+    storage: false
+    schema:
+      openAPIV3Schema:
+        type: object
+`
+
+func TestReadOnlyProperties(t *testing.T) {
+	readOnly, nested, err := readOnlyProperties([][]byte{[]byte(readOnlyCRD)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]map[string][]string{
+		"crontabs.stable.example.com": {"v1": {"lastScheduleTime", "observedGeneration"}},
+	}
+	if !reflect.DeepEqual(want, readOnly) {
+		t.Errorf("expected %v, got %v", want, readOnly)
+	}
+	wantNested := map[string]map[string][]string{
+		"crontabs.stable.example.com": {"v1": {".spec.image", ".spec.ports[*].hostPort", ".status.active"}},
+	}
+	if !reflect.DeepEqual(wantNested, nested) {
+		t.Errorf("expected nested %v, got %v", wantNested, nested)
+	}
+}
+
+func TestReadOnlyDiagnostics(t *testing.T) {
+	crd := filterTestCRD("stable.example.com", "CronTab", "v1")
+	nested := map[string][]string{"v1": {".spec.image", ".status.active"}}
+	diagnostics := readOnlyDiagnostics(crd, nested, []string{"status"})
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diagnostics)
+	}
+	if d := diagnostics[0]; d.CRD != crd.Name || d.Version != "v1" || d.Path != ".spec.image" {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+	if diagnostics := readOnlyDiagnostics(crd, nested, nil); len(diagnostics) != 2 {
+		t.Errorf("expected 2 diagnostics without output-only status, got %v", diagnostics)
+	}
+}
+
+func TestValidateOutputOnly(t *testing.T) {
+	for _, field := range []string{"status", "CronTab.status", "x-status"} {
+		if err := validateOutputOnly([]string{field}); err != nil {
+			t.Errorf("unexpected error for %q: %v", field, err)
+		}
+	}
+	for _, field := range []string{"", "spec.image", "CronTab.spec.image", ".status", "CronTab."} {
+		if err := validateOutputOnly([]string{field}); err == nil {
+			t.Errorf("expected an error for %q", field)
+		}
+	}
+}
+
+func TestSetOutputOnly(t *testing.T) {
+	resource := func() pschema.ResourceSpec {
+		properties := func() map[string]pschema.PropertySpec {
+			return map[string]pschema.PropertySpec{
+				"spec":             {TypeSpec: pschema.TypeSpec{Type: Object}},
+				"status":           {TypeSpec: pschema.TypeSpec{Type: Object}},
+				"lastScheduleTime": {TypeSpec: pschema.TypeSpec{Type: String}},
+				"schedule":         {TypeSpec: pschema.TypeSpec{Type: String}},
+			}
+		}
+		return pschema.ResourceSpec{
+			ObjectTypeSpec:  pschema.ObjectTypeSpec{Properties: properties()},
+			InputProperties: properties(),
+			RequiredInputs:  []string{"spec", "schedule"},
+		}
+	}
+	pkgSpec := &pschema.PackageSpec{Resources: map[string]pschema.ResourceSpec{
+		"kubernetes:stable.example.com/v1:CronTab":      resource(),
+		"kubernetes:stable.example.com/v1:CronTabPatch": resource(),
+		"kubernetes:stable.example.com/v1:Other":        resource(),
+	}}
+	pg := &PackageGenerator{
+		CustomResourceGenerators: []CustomResourceGenerator{
+			{
+				Group:    "stable.example.com",
+				Kind:     "CronTab",
+				Versions: []string{"v1"},
+				readOnly: map[string][]string{"v1": {"lastScheduleTime"}},
+			},
+			{
+				Group:    "stable.example.com",
+				Kind:     "Other",
+				Versions: []string{"v1"},
+			},
+		},
+		outputOnly: []string{"status", "CronTab.schedule"},
+	}
+	pg.setOutputOnly(pkgSpec)
+
+	tests := []struct {
+		token          string
+		wantInputs     []string
+		wantRequired   []string
+		wantProperties int
+	}{
+		{"kubernetes:stable.example.com/v1:CronTab", []string{"spec"}, []string{"spec"}, 4},
+		{"kubernetes:stable.example.com/v1:CronTabPatch", []string{"spec"}, []string{"spec"}, 4},
+		{"kubernetes:stable.example.com/v1:Other", []string{"lastScheduleTime", "schedule", "spec"}, []string{"spec", "schedule"}, 4},
+	}
+	for _, tt := range tests {
+		resource := pkgSpec.Resources[tt.token]
+		var inputs []string
+		for name := range resource.InputProperties {
+			inputs = append(inputs, name)
+		}
+		slices.Sort(inputs)
+		if !reflect.DeepEqual(tt.wantInputs, inputs) {
+			t.Errorf("expected %s to have inputs %v, got %v", tt.token, tt.wantInputs, inputs)
+		}
+		if !reflect.DeepEqual(tt.wantRequired, resource.RequiredInputs) {
+			t.Errorf("expected %s to require %v, got %v", tt.token, tt.wantRequired, resource.RequiredInputs)
+		}
+		if len(resource.Properties) != tt.wantProperties {
+			t.Errorf("expected %s to keep %d output properties, got %d", tt.token, tt.wantProperties, len(resource.Properties))
+		}
+	}
+}
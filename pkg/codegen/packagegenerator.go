@@ -17,9 +17,9 @@ package codegen
 import (
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/pulumi/crd2pulumi/internal/unstruct"
-	"github.com/pulumi/crd2pulumi/internal/versions"
 	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 )
@@ -41,6 +41,22 @@ type PackageGenerator struct {
 	Types map[string]pschema.ComplexTypeSpec
 	// Version is the semver that will be stamped into the generated package
 	Version string
+	// Skipped describes the CRDs and versions that were excluded by the
+	// Filter given to ReadPackagesFromSource
+	Skipped []SkippedCRD
+	// outputOnly are the top-level properties removed from every resource's inputs
+	outputOnly []string
+	// immutable are the property paths replaced on changes besides the detected ones
+	immutable []string
+	// groupModules maps every API group to the module it is generated into
+	groupModules map[string]string
+	// lookupFunctions adds a lookup function for every resource
+	lookupFunctions bool
+	// strict makes the generated resources of cluster-scoped kinds fail instead of warning when a namespace is set
+	strict bool
+	// packageSpec is the Pulumi package spec built from the CRDs. It is shared
+	// by the copies made by forVersion, so it is only built once per source
+	packageSpec *pschema.PackageSpec
 	// schemaPackage is the Pulumi schema package used to generate code for
 	// languages that do not need an ObjectMeta type (NodeJS)
 	schemaPackage *pschema.Package
@@ -49,9 +65,45 @@ type PackageGenerator struct {
 	schemaPackageWithObjectMetaType *pschema.Package
 }
 
+// PackageOption configures how ReadPackagesFromSource turns CRDs into a PackageGenerator.
+type PackageOption func(*packageOptions)
+
+type packageOptions struct {
+	filter          Filter
+	groupModules    map[string]string
+	outputOnly      []string
+	immutable       []string
+	lookupFunctions bool
+	strict          bool
+}
+
+// WithFilter generates only the CRDs and versions selected by `filter`.
+func WithFilter(filter Filter) PackageOption {
+	return func(opts *packageOptions) {
+		opts.filter = filter
+	}
+}
+
 // ReadPackagesFromSource reads one or more documents and returns a PackageGenerator that can be used to generate Pulumi code.
 // Calling this function will fully read and close each document.
-func ReadPackagesFromSource(version string, yamlSources []io.ReadCloser) (*PackageGenerator, error) {
+func ReadPackagesFromSource(version string, yamlSources []io.ReadCloser, opts ...PackageOption) (*PackageGenerator, error) {
+	options := packageOptions{outputOnly: DefaultOutputOnly}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := options.filter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateGroupModules(options.groupModules); err != nil {
+		return nil, err
+	}
+	if err := validateOutputOnly(options.outputOnly); err != nil {
+		return nil, err
+	}
+	if err := validateImmutable(options.immutable); err != nil {
+		return nil, err
+	}
+
 	yamlData := make([][]byte, len(yamlSources))
 
 	for i, yamlSource := range yamlSources {
@@ -72,6 +124,17 @@ func ReadPackagesFromSource(version string, yamlSources []io.ReadCloser) (*Packa
 		return nil, fmt.Errorf("could not find any CRD YAML files")
 	}
 
+	readOnly, nestedReadOnly, err := readOnlyProperties(yamlData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter before building the generators, so excluded CRDs never reach schema generation.
+	crds, skipped := options.filter.Apply(crds)
+	if len(crds) == 0 {
+		return nil, fmt.Errorf("all %d CRDs were excluded by the filters", len(skipped))
+	}
+
 	resourceTokensSize := 0
 	groupVersionsSize := 0
 
@@ -81,6 +144,8 @@ func ReadPackagesFromSource(version string, yamlSources []io.ReadCloser) (*Packa
 		if err != nil {
 			return nil, fmt.Errorf("could not parse crd %d: %w", i, err)
 		}
+		crg.readOnly = readOnly[crd.Name]
+		crg.nestedReadOnly = nestedReadOnly[crd.Name]
 		resourceTokensSize += len(crg.ResourceTokens)
 		groupVersionsSize += len(crg.GroupVersions)
 		crgs = append(crgs, crg)
@@ -88,9 +153,18 @@ func ReadPackagesFromSource(version string, yamlSources []io.ReadCloser) (*Packa
 
 	baseRefs := make([]string, 0, resourceTokensSize)
 	groupVersions := make([]string, 0, groupVersionsSize)
+	groups := make([]string, 0, len(crgs))
 	for _, crg := range crgs {
 		baseRefs = append(baseRefs, crg.ResourceTokens...)
 		groupVersions = append(groupVersions, crg.GroupVersions...)
+		if !slices.Contains(groups, crg.Group) {
+			groups = append(groups, crg.Group)
+		}
+	}
+
+	modules, err := groupModules(groups, options.groupModules)
+	if err != nil {
+		return nil, err
 	}
 
 	pg := &PackageGenerator{
@@ -98,15 +172,73 @@ func ReadPackagesFromSource(version string, yamlSources []io.ReadCloser) (*Packa
 		ResourceTokens:           baseRefs,
 		GroupVersions:            groupVersions,
 		Version:                  version,
+		Skipped:                  skipped,
+		outputOnly:               options.outputOnly,
+		immutable:                options.immutable,
+		groupModules:             modules,
+		lookupFunctions:          options.lookupFunctions,
+		strict:                   options.strict,
 	}
 	return pg, nil
 }
 
+// Diagnostics returns the fields of the CRD schemas that the generated code can't represent exactly, by CRD, version
+// and path. They are read from the package spec, which is built if it wasn't already.
+func (pg *PackageGenerator) Diagnostics() ([]Diagnostic, error) {
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Pulumi package spec: %w", err)
+	}
+	var diagnostics []Diagnostic
+	for _, crg := range pg.CustomResourceGenerators {
+		crd := crg.CustomResourceDefinition
+		found := append(schemaDiagnostics(pkgSpec, crd), readOnlyDiagnostics(crd, crg.nestedReadOnly, pg.outputOnly)...)
+		diagnostics = append(diagnostics, sortDiagnostics(crd, found)...)
+	}
+	return diagnostics, nil
+}
+
+// PackageSpec returns the Pulumi package spec generated from the CRDs, building
+// it on first use.
+func (pg *PackageGenerator) PackageSpec() (*pschema.PackageSpec, error) {
+	if pg.packageSpec == nil {
+		pkgSpec, err := genPackageSpec(pg.CustomResourceGenerators)
+		if err != nil {
+			return nil, err
+		}
+		if err := pg.setLanguageModules(pkgSpec); err != nil {
+			return nil, err
+		}
+		pg.setOutputOnly(pkgSpec)
+		pg.setReplaceOnChanges(pkgSpec)
+		if pg.lookupFunctions {
+			for _, crg := range pg.CustomResourceGenerators {
+				addLookupFunctions(pkgSpec, crg.CustomResourceDefinition)
+			}
+		}
+		pg.packageSpec = pkgSpec
+	}
+	return pg.packageSpec, nil
+}
+
+// forVersion returns a copy of the PackageGenerator stamped with `version`. The
+// copy shares the parsed CRDs and package spec, but binds its own schema
+// packages, since the language generators mutate them.
+func (pg *PackageGenerator) forVersion(version string) *PackageGenerator {
+	copied := *pg
+	copied.Version = version
+	copied.schemaPackage = nil
+	copied.schemaPackageWithObjectMetaType = nil
+	return &copied
+}
+
 // SchemaPackage returns the Pulumi schema package with no ObjectMeta type.
 // This is only necessary for NodeJS and Python.
 func (pg *PackageGenerator) SchemaPackage() *pschema.Package {
 	if pg.schemaPackage == nil {
-		pkg, err := genPackage(pg.Version, pg.CustomResourceGenerators, false)
+		pkgSpec, err := pg.PackageSpec()
+		contract.AssertNoErrorf(err, "could not generate Pulumi package spec")
+		pkg, err := genPackage(pg.Version, *pkgSpec, false)
 		contract.AssertNoErrorf(err, "could not parse Pulumi package")
 		pg.schemaPackage = pkg
 	}
@@ -117,7 +249,9 @@ func (pg *PackageGenerator) SchemaPackage() *pschema.Package {
 // an ObjectMeta type. This is only necessary for Go and .NET.
 func (pg *PackageGenerator) SchemaPackageWithObjectMetaType() *pschema.Package {
 	if pg.schemaPackageWithObjectMetaType == nil {
-		pkg, err := genPackage(pg.Version, pg.CustomResourceGenerators, true)
+		pkgSpec, err := pg.PackageSpec()
+		contract.AssertNoErrorf(err, "could not generate Pulumi package spec")
+		pkg, err := genPackage(pg.Version, *pkgSpec, true)
 		contract.AssertNoErrorf(err, "could not parse Pulumi package")
 		pg.schemaPackageWithObjectMetaType = pkg
 	}
@@ -125,21 +259,10 @@ func (pg *PackageGenerator) SchemaPackageWithObjectMetaType() *pschema.Package {
 }
 
 // Returns language-specific 'ModuleToPackage' map. Creates a mapping from
-// every groupVersion string <group>/<version> to <groupPrefix>/<version>.
+// every groupVersion string <group>/<version> to <module>/<version>, where
+// <module> is the group's prefix unless overridden or disambiguated.
 func (pg *PackageGenerator) ModuleToPackage() (map[string]string, error) {
-	moduleToPackage := map[string]string{}
-	for _, groupVersion := range pg.GroupVersions {
-		group, version, err := versions.SplitGroupVersion(groupVersion)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version: %w", err)
-		}
-		prefix, err := versions.GroupPrefix(group)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version: %w", err)
-		}
-		moduleToPackage[groupVersion] = prefix + "/" + version
-	}
-	return moduleToPackage, nil
+	return pg.languageModules("go")
 }
 
 // HasSchemas returns true if there exists at least one CustomResource with a schema in this package.
@@ -65,6 +65,20 @@ func GeneratePython(pg *PackageGenerator, cs *CodegenSettings) (map[string]*byte
 		files[metaPath] = append(code, []byte(pythonMetaFile)...)
 	}
 
+	if err := checkClusterScopedNamespaces(pg, Python, files); err != nil {
+		return nil, err
+	}
+	if cs.ValidationHelpers {
+		if err := addValidationHelpers(pg, Python, pythonPackageDir, files); err != nil {
+			return nil, err
+		}
+	}
+	if pg.lookupFunctions {
+		if err := writeLookupFunctions(pg, Python, files); err != nil {
+			return nil, err
+		}
+	}
+
 	buffers := map[string]*bytes.Buffer{}
 	for name, code := range files {
 		if name == "pyproject.toml" {
@@ -27,6 +27,7 @@ import (
 	"github.com/pulumi/crd2pulumi/internal/unstruct"
 	"github.com/pulumi/pulumi-kubernetes/provider/v4/pkg/gen"
 	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apiextensions-apiserver/pkg/controller/openapi/builder"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/kube-openapi/pkg/validation/spec"
@@ -69,6 +70,7 @@ var emptySpec = pschema.ComplexTypeSpec{
 
 const (
 	objectMetaRef        = "#/types/kubernetes:meta/v1:ObjectMeta"
+	objectMetaPatchRef   = "#/types/kubernetes:meta/v1:ObjectMetaPatch"
 	objectMetaToken      = "kubernetes:meta/v1:ObjectMeta"
 	objectMetaPatchToken = "kubernetes:meta/v1:ObjectMetaPatch"
 )
@@ -99,10 +101,9 @@ func mergeSpecs(specs []*spec.Swagger) (*spec.Swagger, error) {
 	return mergedSpecs, nil
 }
 
-// Returns the Pulumi package given a types map and a slice of the token types
-// of every CustomResource. If includeObjectMetaType is true, then a
-// ObjectMetaType type is also generated.
-func genPackage(version string, crgenerators []CustomResourceGenerator, includeObjectMetaType bool) (*pschema.Package, error) {
+// genPackageSpec returns the Pulumi package spec for the given CustomResourceGenerators. Building the spec is the
+// expensive part of code generation, so it is shared by every language via genPackage.
+func genPackageSpec(crgenerators []CustomResourceGenerator) (*pschema.PackageSpec, error) {
 	var allCRDSpecs []*spec.Swagger
 	// Merge all OpenAPI specs into a single OpenAPI spec.
 	for _, crg := range crgenerators {
@@ -113,7 +114,7 @@ func genPackage(version string, crgenerators []CustomResourceGenerator, includeO
 
 	mergedSpec, err := mergeSpecs(allCRDSpecs)
 	if err != nil {
-		return &pschema.Package{}, fmt.Errorf("could not merge OpenAPI specs: %w", err)
+		return nil, fmt.Errorf("could not merge OpenAPI specs: %w", err)
 	}
 
 	marshaledOpenAPISchema, err := json.Marshal(mergedSpec)
@@ -136,15 +137,9 @@ func genPackage(version string, crgenerators []CustomResourceGenerator, includeO
 	// Populate the package spec with information used in previous versions of crd2pulumi to maintain consistency
 	// with older versions.
 	pkgSpec.Name = pulumiKubernetesNameShim
-	pkgSpec.Version = version
 	pkgSpec.Config = pschema.ConfigSpec{}
 	pkgSpec.Provider = pschema.ResourceSpec{}
 
-	if !includeObjectMetaType {
-		delete(pkgSpec.Types, objectMetaToken)
-		delete(pkgSpec.Types, objectMetaPatchToken)
-	}
-
 	// Remove excess resources generated from the OpenAPI spec.
 	for resourceName := range pkgSpec.Resources {
 		if strings.HasPrefix(resourceName, "kubernetes:meta/v1:") {
@@ -152,6 +147,57 @@ func genPackage(version string, crgenerators []CustomResourceGenerator, includeO
 		}
 	}
 
+	for _, crg := range crgenerators {
+		addEmbeddedResources(&pkgSpec, crg.CustomResourceDefinition)
+		addEnums(&pkgSpec, crg.CustomResourceDefinition)
+		addConstraints(&pkgSpec, crg.CustomResourceDefinition)
+		addMergeKeys(&pkgSpec, crg.CustomResourceDefinition)
+		addScope(&pkgSpec, crg.CustomResourceDefinition)
+		deprecateVersions(&pkgSpec, crg.CustomResourceDefinition)
+	}
+
+	return &pkgSpec, nil
+}
+
+// deprecateVersions sets the DeprecationMessage of the resources generated for
+// every version of `crd` marked `deprecated`, so the generated SDKs flag them.
+// The message is the version's `deprecationWarning`, or the warning the API
+// server would return by default.
+func deprecateVersions(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) {
+	for _, v := range crd.Spec.Versions {
+		if !v.Deprecated {
+			continue
+		}
+		message := fmt.Sprintf("%s/%s %s is deprecated", crd.Spec.Group, v.Name, crd.Spec.Names.Kind)
+		if v.DeprecationWarning != nil {
+			message = *v.DeprecationWarning
+		}
+
+		token := getToken(crd.Spec.Group, v.Name, crd.Spec.Names.Kind)
+		for _, t := range []string{token, token + "List", token + "Patch"} {
+			if resource, ok := pkgSpec.Resources[t]; ok {
+				resource.DeprecationMessage = message
+				pkgSpec.Resources[t] = resource
+			}
+		}
+	}
+}
+
+// Returns the Pulumi package for the given package spec, stamped with `version`. If includeObjectMetaType is true,
+// then a ObjectMetaType type is also generated. The given spec is not modified, so it may be shared.
+func genPackage(version string, pkgSpec pschema.PackageSpec, includeObjectMetaType bool) (*pschema.Package, error) {
+	pkgSpec.Version = version
+
+	if !includeObjectMetaType {
+		types := make(map[string]pschema.ComplexTypeSpec, len(pkgSpec.Types))
+		for token, typ := range pkgSpec.Types {
+			if token != objectMetaToken && token != objectMetaPatchToken {
+				types[token] = typ
+			}
+		}
+		pkgSpec.Types = types
+	}
+
 	pkg, err := pschema.ImportSpec(pkgSpec, nil, pschema.ValidationOptions{})
 	if err != nil {
 		msg, err2 := func() (string, error) {
@@ -0,0 +1,57 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestDeprecateVersions(t *testing.T) {
+	warning := "stable.example.com/v1beta1 CronTab is deprecated; use stable.example.com/v1 CronTab"
+	crd := filterTestCRD("stable.example.com", "CronTab")
+	crd.Spec.Versions = []extensionv1.CustomResourceDefinitionVersion{
+		{Name: "v1alpha1", Deprecated: true},
+		{Name: "v1beta1", Deprecated: true, DeprecationWarning: &warning},
+		{Name: "v1"},
+	}
+
+	pkgSpec := &pschema.PackageSpec{Resources: map[string]pschema.ResourceSpec{
+		"kubernetes:stable.example.com/v1alpha1:CronTab":      {},
+		"kubernetes:stable.example.com/v1alpha1:CronTabPatch": {},
+		"kubernetes:stable.example.com/v1beta1:CronTab":       {},
+		"kubernetes:stable.example.com/v1beta1:CronTabList":   {},
+		"kubernetes:stable.example.com/v1:CronTab":            {},
+	}}
+	deprecateVersions(pkgSpec, crd)
+
+	want := map[string]string{
+		"kubernetes:stable.example.com/v1alpha1:CronTab":      "stable.example.com/v1alpha1 CronTab is deprecated",
+		"kubernetes:stable.example.com/v1alpha1:CronTabPatch": "stable.example.com/v1alpha1 CronTab is deprecated",
+		"kubernetes:stable.example.com/v1beta1:CronTab":       warning,
+		"kubernetes:stable.example.com/v1beta1:CronTabList":   warning,
+		"kubernetes:stable.example.com/v1:CronTab":            "",
+	}
+	if len(pkgSpec.Resources) != len(want) {
+		t.Errorf("expected %d resources, got %d", len(want), len(pkgSpec.Resources))
+	}
+	for token, message := range want {
+		if got := pkgSpec.Resources[token].DeprecationMessage; got != message {
+			t.Errorf("expected %s to have deprecation message %q, got %q", token, message, got)
+		}
+	}
+}
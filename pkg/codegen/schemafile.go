@@ -0,0 +1,74 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	SchemaFormatJSON string = "json"
+	SchemaFormatYAML string = "yaml"
+)
+
+// GenerateSchema returns the Pulumi package spec that the language SDKs are
+// generated from, as `schema.json` or `schema.yaml` depending on
+// cs.SchemaFormat. The package is named cs.PackageName, so that an SDK
+// generated from it with `pulumi package gen-sdk` doesn't collide with the
+// Kubernetes provider's own `kubernetes` package. Every resource and type token
+// stays in the `kubernetes` package, which is allowed in addition to the name,
+// since the resources are managed by the Kubernetes provider.
+func GenerateSchema(pg *PackageGenerator, cs *CodegenSettings) (map[string]*bytes.Buffer, error) {
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Pulumi package spec: %w", err)
+	}
+	versionedSpec := *pkgSpec
+	versionedSpec.Version = pg.Version
+	versionedSpec.Name = cs.PackageName
+	if versionedSpec.Name == "" {
+		versionedSpec.Name = DefaultName
+	}
+	if versionedSpec.Name == pulumiKubernetesNameShim {
+		return nil, fmt.Errorf("schema package name %q collides with the Kubernetes provider's package", versionedSpec.Name)
+	}
+	versionedSpec.AllowedPackageNames = []string{pulumiKubernetesNameShim}
+
+	data, err := json.MarshalIndent(versionedSpec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal Pulumi package spec: %w", err)
+	}
+
+	switch cs.SchemaFormat {
+	case "", SchemaFormatJSON:
+		return map[string]*bytes.Buffer{
+			"schema.json": bytes.NewBuffer(append(data, '\n')),
+		}, nil
+	case SchemaFormatYAML:
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert Pulumi package spec to YAML: %w", err)
+		}
+		return map[string]*bytes.Buffer{
+			"schema.yaml": bytes.NewBuffer(data),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported schema format %q, must be %q or %q", cs.SchemaFormat, SchemaFormatJSON, SchemaFormatYAML)
+	}
+}
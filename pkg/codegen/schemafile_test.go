@@ -0,0 +1,104 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	newPackageGenerator := func() *PackageGenerator {
+		return &PackageGenerator{
+			Version: "1.2.3",
+			packageSpec: &pschema.PackageSpec{
+				Name: pulumiKubernetesNameShim,
+				Types: map[string]pschema.ComplexTypeSpec{
+					"kubernetes:stable.example.com/v1:CronTabSpec": {
+						ObjectTypeSpec: pschema.ObjectTypeSpec{
+							Type: Object,
+							Properties: map[string]pschema.PropertySpec{
+								"cronSpec": {TypeSpec: pschema.TypeSpec{Type: String}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		packageName string
+		format      string
+		fileName    string
+		wantName    string
+		unmarshal   func([]byte, any) error
+	}{
+		{name: "Default", format: "", fileName: "schema.json", wantName: DefaultName, unmarshal: json.Unmarshal},
+		{name: "JSON", packageName: "certmanager", format: SchemaFormatJSON, fileName: "schema.json", wantName: "certmanager", unmarshal: json.Unmarshal},
+		{name: "YAML", packageName: "certmanager", format: SchemaFormatYAML, fileName: "schema.yaml", wantName: "certmanager", unmarshal: func(data []byte, v any) error {
+			return yaml.Unmarshal(data, v)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := newPackageGenerator()
+			files, err := GenerateSchema(pg, &CodegenSettings{Language: Schema, PackageName: tt.packageName, SchemaFormat: tt.format})
+			if err != nil {
+				t.Fatalf("GenerateSchema() error = %v", err)
+			}
+			if len(files) != 1 || files[tt.fileName] == nil {
+				t.Fatalf("expected only %s, got %v", tt.fileName, files)
+			}
+
+			var got pschema.PackageSpec
+			if err := tt.unmarshal(files[tt.fileName].Bytes(), &got); err != nil {
+				t.Fatalf("could not unmarshal %s: %v", tt.fileName, err)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("expected name %s, got %s", tt.wantName, got.Name)
+			}
+			if len(got.AllowedPackageNames) != 1 || got.AllowedPackageNames[0] != pulumiKubernetesNameShim {
+				t.Errorf("expected the %s package to be allowed, got %v", pulumiKubernetesNameShim, got.AllowedPackageNames)
+			}
+			if pg.packageSpec.Name != pulumiKubernetesNameShim {
+				t.Errorf("expected the shared package spec to keep its name")
+			}
+			if got.Version != "1.2.3" {
+				t.Errorf("expected version 1.2.3, got %s", got.Version)
+			}
+			if _, ok := got.Types["kubernetes:stable.example.com/v1:CronTabSpec"]; !ok {
+				t.Errorf("expected CronTabSpec type, got %v", got.Types)
+			}
+			if pg.packageSpec.Version != "" {
+				t.Errorf("expected the shared package spec to be left unversioned")
+			}
+		})
+	}
+
+	_, err := GenerateSchema(newPackageGenerator(), &CodegenSettings{Language: Schema, SchemaFormat: "toml"})
+	if err == nil {
+		t.Errorf("expected an error for an unsupported schema format")
+	}
+	_, err = GenerateSchema(newPackageGenerator(), &CodegenSettings{Language: Schema, PackageName: "kubernetes"})
+	if err == nil {
+		t.Errorf("expected an error for a package named kubernetes")
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// WithStrict makes the generated resources of cluster-scoped kinds fail when a
+// namespace is set on them, instead of logging a warning.
+func WithStrict(strict bool) PackageOption {
+	return func(opts *packageOptions) {
+		opts.strict = strict
+	}
+}
+
+// describeClusterScoped returns a sentence saying that objects of `kind` are
+// cluster-scoped, for the descriptions of their resources and metadata.
+func describeClusterScoped(kind string) string {
+	return kind + " is cluster-scoped, so `metadata.namespace` must not be set: the API server ignores it."
+}
+
+// clusterScopedNamespaceError is the message `crd2pulumi validate` reports
+// when the namespace of a cluster-scoped object of `kind` is set, like the
+// generated validation helpers.
+func clusterScopedNamespaceError(kind string) string {
+	return "must not be set, " + kind + " is cluster-scoped"
+}
+
+// addScope documents on the resources generated from `crd`, and on their
+// metadata, that the kind is cluster-scoped if it is. The API server silently
+// drops the namespace of cluster-scoped objects, so a namespace set by mistake,
+// e.g. on a ClusterIssuer, otherwise goes unnoticed.
+func addScope(pkgSpec *pschema.PackageSpec, crd extensionv1.CustomResourceDefinition) {
+	if crd.Spec.Scope != extensionv1.ClusterScoped {
+		return
+	}
+	doc := describeClusterScoped(crd.Spec.Names.Kind)
+	for _, v := range crd.Spec.Versions {
+		token := getToken(crd.Spec.Group, v.Name, crd.Spec.Names.Kind)
+		for _, t := range []string{token, token + "Patch"} {
+			resource, ok := pkgSpec.Resources[t]
+			if !ok {
+				continue
+			}
+			resource.Description = appendDocs(resource.Description, doc)
+			for _, properties := range []map[string]pschema.PropertySpec{resource.InputProperties, resource.Properties} {
+				if metadata, ok := properties["metadata"]; ok {
+					metadata.Description = appendDocs(metadata.Description, doc)
+					properties["metadata"] = metadata
+				}
+			}
+			pkgSpec.Resources[t] = resource
+		}
+	}
+}
+
+// namespaceCheck is the check of the namespace added to the constructors of
+// cluster-scoped resources in a language.
+type namespaceCheck struct {
+	// code checks the metadata of the resource, reporting {{message}} with
+	// {{report}}.
+	code string
+	// warn and fail report {{message}} as a warning, or as an error in strict
+	// packages.
+	warn, fail string
+	// insert adds `check` to the constructor of the resource `token` in `code`,
+	// returning false if the constructor isn't found.
+	insert func(code []byte, token, check string) ([]byte, bool)
+}
+
+var namespaceChecks = map[string]namespaceCheck{
+	NodeJS: {
+		code: `// {{kind}} is cluster-scoped, so the API server ignores a namespace set on it.
+if (!opts.id && resourceInputs["metadata"] !== undefined) {
+    resourceInputs["metadata"] = pulumi.output(resourceInputs["metadata"]).apply((metadata: any) => {
+        if (metadata?.namespace) {
+            {{report}}
+        }
+        return metadata;
+    });
+}
+`,
+		warn: `pulumi.log.warn({{message}});`,
+		fail: `throw new Error({{message}});`,
+		insert: func(code []byte, token, check string) ([]byte, bool) {
+			return insertBefore(code, regexp.MustCompile(`(?m)^([ \t]*)super\(\w+\.__pulumiType, name, resourceInputs, opts\);`), check)
+		},
+	},
+	Python: {
+		code: `# {{kind}} is cluster-scoped, so the API server ignores a namespace set on it.
+if opts.id is None and __props__.__dict__["metadata"] is not None:
+    def check_namespace(metadata):
+        namespace = metadata.get("namespace") if isinstance(metadata, dict) else getattr(metadata, "namespace", None)
+        if namespace:
+            {{report}}
+        return metadata
+    __props__.__dict__["metadata"] = pulumi.Output.from_input(__props__.__dict__["metadata"]).apply(check_namespace)
+`,
+		warn: `pulumi.log.warn({{message}})`,
+		fail: `raise ValueError({{message}})`,
+		insert: func(code []byte, token, check string) ([]byte, bool) {
+			// The constructor passes the token to the constructor of its superclass, on the line after super(.
+			return insertBefore(code, regexp.MustCompile(`(?m)^([ \t]*)super\(.*\n\s*`+regexp.QuoteMeta("'"+token+"',")), check)
+		},
+	},
+	Go: {
+		code: `// {{kind}} is cluster-scoped, so the API server ignores a namespace set on it.
+if args.Metadata != nil {
+	args.Metadata = args.Metadata.To{{metadata}}PtrOutput().ApplyT(func(metadata *{{meta}}.{{metadata}}) (*{{meta}}.{{metadata}}, error) {
+		if metadata != nil && metadata.Namespace != nil && *metadata.Namespace != "" {
+			{{report}}
+		}
+		return metadata, nil
+	}).({{meta}}.{{metadata}}PtrOutput)
+}
+`,
+		warn: `_ = ctx.Log.Warn({{message}}, nil)`,
+		fail: `return nil, errors.New({{message}})`,
+		insert: func(code []byte, token, check string) ([]byte, bool) {
+			// The type of the metadata, which is ObjectMetaPatch in Patch resources, is in the Args of the resource.
+			metadata := goMetadataPattern.FindSubmatch(code)
+			if metadata == nil {
+				return nil, false
+			}
+			check = strings.NewReplacer("{{meta}}", string(metadata[1]), "{{metadata}}", string(metadata[2])).Replace(check)
+			register := regexp.MustCompile(`(?m)^([ \t]*)err := ctx\.RegisterResource\(` + regexp.QuoteMeta(strconv.Quote(token)))
+			code, ok := insertBefore(code, register, check)
+			if !ok {
+				return nil, false
+			}
+			if strings.Contains(check, "errors.New") && !strings.Contains(string(code), `"errors"`) {
+				code = []byte(strings.Replace(string(code), "import (\n", "import (\n\t\"errors\"\n", 1))
+			}
+			code, err := format.Source(code)
+			return code, err == nil
+		},
+	},
+}
+
+// goMetadataPattern matches the metadata field of the Args of a Go resource,
+// capturing the package and name of its type.
+var goMetadataPattern = regexp.MustCompile(`(?m)^\s*Metadata\s+(\w+)\.(\w+)PtrInput\b`)
+
+// insertBefore inserts `check` before the line matched by `pattern` in
+// `code`, indented like it. The first group of `pattern` must match the
+// indentation. Returns false if nothing matches.
+func insertBefore(code []byte, pattern *regexp.Regexp, check string) ([]byte, bool) {
+	match := pattern.FindSubmatchIndex(code)
+	if match == nil {
+		return nil, false
+	}
+	indent := string(code[match[2]:match[3]])
+	var b strings.Builder
+	b.Write(code[:match[0]])
+	for _, line := range strings.SplitAfter(check, "\n") {
+		if strings.TrimSpace(line) != "" {
+			b.WriteString(indent)
+		}
+		b.WriteString(line)
+	}
+	b.Write(code[match[0]:])
+	return []byte(b.String()), true
+}
+
+// checkClusterScopedNamespaces adds a check of the namespace to the
+// constructors of the resources generated for cluster-scoped kinds in `files`.
+// The API server silently drops the namespace of cluster-scoped objects, so
+// the check logs a warning when one is set, or fails the resource if the
+// package is strict. Values that aren't known yet are checked once they are.
+func checkClusterScopedNamespaces(pg *PackageGenerator, language string, files map[string][]byte) error {
+	check, ok := namespaceChecks[language]
+	if !ok {
+		return fmt.Errorf("namespace checks are not supported for %s", language)
+	}
+	report := check.warn
+	if pg.strict {
+		report = check.fail
+	}
+	for _, crg := range pg.CustomResourceGenerators {
+		if !crg.IsClusterScoped() {
+			continue
+		}
+		code := strings.NewReplacer(
+			"{{kind}}", crg.Kind,
+			"{{report}}", strings.ReplaceAll(report, "{{message}}", strconv.Quote(".metadata.namespace: "+clusterScopedNamespaceError(crg.Kind))),
+		).Replace(check.code)
+		for _, v := range crg.Versions {
+			for _, kind := range []string{crg.Kind, crg.Kind + "Patch"} {
+				token := getToken(crg.Group, v, kind)
+				file, ok := resourceFile(files, kind, token)
+				if !ok {
+					if kind == crg.Kind {
+						return fmt.Errorf("cannot check namespaces: the generated %s code has no %s resource", language, token)
+					}
+					continue
+				}
+				checked, ok := check.insert(files[file], token, code)
+				if !ok {
+					return fmt.Errorf("cannot check namespaces: %s has no constructor for %s", file, token)
+				}
+				files[file] = checked
+			}
+		}
+	}
+	return nil
+}
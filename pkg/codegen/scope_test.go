@@ -0,0 +1,249 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestAddScope(t *testing.T) {
+	newPkgSpec := func() *pschema.PackageSpec {
+		resource := func() pschema.ResourceSpec {
+			metadata := func() map[string]pschema.PropertySpec {
+				return map[string]pschema.PropertySpec{
+					"metadata": {TypeSpec: pschema.TypeSpec{Type: Object, Ref: objectMetaRef}, Description: "Standard object's metadata."},
+				}
+			}
+			return pschema.ResourceSpec{
+				ObjectTypeSpec:  pschema.ObjectTypeSpec{Description: "A ClusterIssuer issues certificates.", Properties: metadata()},
+				InputProperties: metadata(),
+			}
+		}
+		return &pschema.PackageSpec{Resources: map[string]pschema.ResourceSpec{
+			"kubernetes:cert-manager.io/v1:ClusterIssuer":      resource(),
+			"kubernetes:cert-manager.io/v1:ClusterIssuerPatch": resource(),
+		}}
+	}
+
+	crd := filterTestCRD("cert-manager.io", "ClusterIssuer", "v1")
+	crd.Spec.Scope = extensionv1.ClusterScoped
+	pkgSpec := newPkgSpec()
+	addScope(pkgSpec, crd)
+
+	doc := "ClusterIssuer is cluster-scoped, so `metadata.namespace` must not be set: the API server ignores it."
+	for token, resource := range pkgSpec.Resources {
+		if want := "A ClusterIssuer issues certificates.\n\n" + doc; resource.Description != want {
+			t.Errorf("expected %s to be described as %q, got %q", token, want, resource.Description)
+		}
+		want := "Standard object's metadata.\n\n" + doc
+		if got := resource.InputProperties["metadata"].Description; got != want {
+			t.Errorf("expected the metadata input of %s to be described as %q, got %q", token, want, got)
+		}
+		if got := resource.Properties["metadata"].Description; got != want {
+			t.Errorf("expected the metadata output of %s to be described as %q, got %q", token, want, got)
+		}
+	}
+
+	crd.Spec.Scope = extensionv1.NamespaceScoped
+	pkgSpec = newPkgSpec()
+	addScope(pkgSpec, crd)
+	for token, resource := range pkgSpec.Resources {
+		if want := "A ClusterIssuer issues certificates."; resource.Description != want {
+			t.Errorf("expected %s to be described as %q, got %q", token, want, resource.Description)
+		}
+	}
+}
+
+const nodejsClusterIssuer = `import * as pulumi from "@pulumi/pulumi";
+import * as utilities from "../../utilities";
+
+export class ClusterIssuer extends pulumi.CustomResource {
+    /** @internal */
+    public static readonly __pulumiType = 'kubernetes:certs.example.com/v1:ClusterIssuer';
+
+    constructor(name: string, args?: ClusterIssuerArgs, opts?: pulumi.CustomResourceOptions) {
+        let resourceInputs: pulumi.Inputs = {};
+        opts = opts || {};
+        if (!opts.id) {
+            resourceInputs["metadata"] = args ? args.metadata : undefined;
+        } else {
+            resourceInputs["metadata"] = undefined /*out*/;
+        }
+        opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
+        super(ClusterIssuer.__pulumiType, name, resourceInputs, opts);
+    }
+}
+`
+
+const pythonClusterIssuer = `class ClusterIssuer(pulumi.CustomResource):
+    def _internal_init(__self__,
+                 resource_name: str,
+                 opts: Optional[pulumi.ResourceOptions] = None,
+                 metadata: Optional[pulumi.Input[Union['_meta.v1.ObjectMetaArgs', '_meta.v1.ObjectMetaArgsDict']]] = None,
+                 __props__=None):
+        opts = pulumi.ResourceOptions.merge(_utilities.get_resource_opts_defaults(), opts)
+        if opts.id is None:
+            __props__ = ClusterIssuerArgs.__new__(ClusterIssuerArgs)
+
+            __props__.__dict__["metadata"] = metadata
+        super(ClusterIssuer, __self__).__init__(
+            'kubernetes:certs.example.com/v1:ClusterIssuer',
+            resource_name,
+            __props__,
+            opts)
+`
+
+const goClusterIssuer = `package v1
+
+import (
+	"context"
+	"reflect"
+
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+type ClusterIssuer struct {
+	pulumi.CustomResourceState
+
+	Metadata metav1.ObjectMetaOutput ` + "`pulumi:\"metadata\"`" + `
+}
+
+// NewClusterIssuer registers a new resource with the given unique name, arguments, and options.
+func NewClusterIssuer(ctx *pulumi.Context,
+	name string, args *ClusterIssuerArgs, opts ...pulumi.ResourceOption) (*ClusterIssuer, error) {
+	if args == nil {
+		args = &ClusterIssuerArgs{}
+	}
+
+	var resource ClusterIssuer
+	err := ctx.RegisterResource("kubernetes:certs.example.com/v1:ClusterIssuer", name, args, &resource, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+type ClusterIssuerArgs struct {
+	Metadata metav1.ObjectMetaPtrInput
+}
+`
+
+func TestCheckClusterScopedNamespaces(t *testing.T) {
+	newPackageGenerator := func(strict bool) *PackageGenerator {
+		return &PackageGenerator{
+			CustomResourceGenerators: []CustomResourceGenerator{
+				{Group: "certs.example.com", Kind: "ClusterIssuer", Versions: []string{"v1"}, Scope: extensionv1.ClusterScoped},
+				{Group: "certs.example.com", Kind: "Issuer", Versions: []string{"v1"}},
+			},
+			strict: strict,
+		}
+	}
+	message := `".metadata.namespace: must not be set, ClusterIssuer is cluster-scoped"`
+
+	tests := []struct {
+		language string
+		file     string
+		code     string
+		strict   bool
+		want     []string
+	}{
+		{
+			language: NodeJS,
+			file:     "certs/v1/clusterIssuer.ts",
+			code:     nodejsClusterIssuer,
+			want: []string{
+				"        if (!opts.id && resourceInputs[\"metadata\"] !== undefined) {\n",
+				"                pulumi.log.warn(" + message + ");\n",
+				"        });\n        }\n        super(ClusterIssuer.__pulumiType, name, resourceInputs, opts);",
+			},
+		},
+		{
+			language: NodeJS,
+			file:     "certs/v1/clusterIssuer.ts",
+			code:     nodejsClusterIssuer,
+			strict:   true,
+			want:     []string{"                throw new Error(" + message + ");\n"},
+		},
+		{
+			language: Python,
+			file:     "pulumi_crds/certs/v1/cluster_issuer.py",
+			code:     pythonClusterIssuer,
+			want: []string{
+				"        if opts.id is None and __props__.__dict__[\"metadata\"] is not None:\n",
+				"                pulumi.log.warn(" + message + ")\n",
+				".apply(check_namespace)\n        super(ClusterIssuer, __self__).__init__(",
+			},
+		},
+		{
+			language: Python,
+			file:     "pulumi_crds/certs/v1/cluster_issuer.py",
+			code:     pythonClusterIssuer,
+			strict:   true,
+			want:     []string{"                raise ValueError(" + message + ")\n"},
+		},
+		{
+			language: Go,
+			file:     "certs/v1/clusterIssuer.go",
+			code:     goClusterIssuer,
+			want: []string{
+				"\t\targs.Metadata = args.Metadata.ToObjectMetaPtrOutput().ApplyT(func(metadata *metav1.ObjectMeta) (*metav1.ObjectMeta, error) {\n",
+				"\t\t\t\t_ = ctx.Log.Warn(" + message + ", nil)\n",
+				"\t\t}).(metav1.ObjectMetaPtrOutput)\n\t}\n\terr := ctx.RegisterResource(",
+			},
+		},
+		{
+			language: Go,
+			file:     "certs/v1/clusterIssuer.go",
+			code:     goClusterIssuer,
+			strict:   true,
+			want:     []string{"\t\t\t\treturn nil, errors.New(" + message + ")\n", "\t\"context\"\n\t\"errors\"\n"},
+		},
+	}
+	for _, tt := range tests {
+		issuer := strings.ReplaceAll(tt.code, "ClusterIssuer", "Issuer")
+		issuerFile := strings.ReplaceAll(tt.file, "cluster_issuer", "issuer")
+		issuerFile = strings.ReplaceAll(issuerFile, "clusterIssuer", "issuer")
+		files := map[string][]byte{tt.file: []byte(tt.code), issuerFile: []byte(issuer)}
+		if err := checkClusterScopedNamespaces(newPackageGenerator(tt.strict), tt.language, files); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.language, err)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(files[tt.file]), want) {
+				t.Errorf("%s: expected %s to contain %q, got:\n%s", tt.language, tt.file, want, files[tt.file])
+			}
+		}
+		if string(files[issuerFile]) != issuer {
+			t.Errorf("%s: expected the namespaced Issuer to be left alone, got:\n%s", tt.language, files[issuerFile])
+		}
+		if tt.language == Go {
+			if formatted, err := format.Source(files[tt.file]); err != nil || string(formatted) != string(files[tt.file]) {
+				t.Errorf("expected gofmt-formatted code, got:\n%s", files[tt.file])
+			}
+		}
+	}
+
+	files := map[string][]byte{"certs/v1/clusterIssuer.ts": []byte("public static readonly __pulumiType = 'kubernetes:certs.example.com/v1:ClusterIssuer';\n")}
+	err := checkClusterScopedNamespaces(newPackageGenerator(false), NodeJS, files)
+	if err == nil || !strings.Contains(err.Error(), "has no constructor") {
+		t.Errorf("expected an error about the missing constructor, got %v", err)
+	}
+}
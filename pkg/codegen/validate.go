@@ -0,0 +1,260 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/crd2pulumi/internal/unstruct"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/listtype"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/objectmeta"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+)
+
+// Validator checks custom resources against the schemas of their CRDs without a cluster, the way the API server does
+// when they are created: unknown fields are reported instead of pruned, defaults are applied, and the resource is
+// validated against the structural schema of its version, including required fields, enums, patterns, formats and the
+// CEL rules of x-kubernetes-validations.
+type Validator struct {
+	// versions maps the <group>/<version>/<kind> of every custom resource to the schema of its version.
+	versions map[string]*validationVersion
+	// groups are the API groups defined by the CRDs.
+	groups map[string]bool
+}
+
+// validationVersion is the schema of a CRD version.
+type validationVersion struct {
+	served bool
+	// clusterScoped is true if the kind has no namespace.
+	clusterScoped bool
+	// structural and validator are nil for versions without a schema, which accept any fields.
+	structural *structuralschema.Structural
+	validator  apiservervalidation.SchemaValidator
+	// cel evaluates the x-kubernetes-validations rules of the schema. It is nil if there are none.
+	cel *cel.Validator
+}
+
+// ValidationError is a problem with a custom resource found by a Validator.
+type ValidationError struct {
+	// Resource is the kind and name of the custom resource, e.g. "Certificate default/my-cert".
+	Resource string
+	// Path is the JSON path of the invalid field, e.g. ".spec.dnsNames[0]".
+	Path string
+	// Message describes the problem.
+	Message string
+}
+
+func (e ValidationError) String() string {
+	return fmt.Sprintf("%s: %s: %s", e.Resource, e.Path, e.Message)
+}
+
+// ValidationResult lists the problems found in the custom resources of a manifest.
+type ValidationResult struct {
+	// Resources is the number of custom resources that were validated.
+	Resources int
+	// Errors are the problems found, in the order of the resources and then by path.
+	Errors []ValidationError
+	// Skipped describes the documents that aren't custom resources of the API groups defined by the CRDs.
+	Skipped []string
+}
+
+// NewValidator returns a Validator for the custom resources defined by the CRDs in `yamlSources`. Calling this function
+// will fully read and close each document.
+func NewValidator(yamlSources []io.ReadCloser) (*Validator, error) {
+	yamlData := make([][]byte, len(yamlSources))
+	for i, yamlSource := range yamlSources {
+		defer yamlSource.Close()
+		var err error
+		yamlData[i], err = io.ReadAll(yamlSource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read YAML: %w", err)
+		}
+	}
+	crds, err := unstruct.UnmarshalYamls(yamlData)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal yaml file(s): %w", err)
+	}
+	if len(crds) == 0 {
+		return nil, fmt.Errorf("could not find any CRD YAML files")
+	}
+
+	v := &Validator{versions: map[string]*validationVersion{}, groups: map[string]bool{}}
+	for i, crd := range crds {
+		crg, err := NewCustomResourceGenerator(crd)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse crd %d: %w", i, err)
+		}
+		v.groups[crg.Group] = true
+		for _, version := range crg.CustomResourceDefinition.Spec.Versions {
+			schema, err := newValidationVersion(version)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schema of %s/%s %s: %w", crg.Group, version.Name, crg.Kind, err)
+			}
+			schema.clusterScoped = crg.IsClusterScoped()
+			v.versions[crg.Group+"/"+version.Name+"/"+crg.Kind] = schema
+		}
+	}
+	return v, nil
+}
+
+// newValidationVersion converts the OpenAPI schema of a CRD version to the structural schema the API server uses.
+func newValidationVersion(version extensionv1.CustomResourceDefinitionVersion) (*validationVersion, error) {
+	schema := &validationVersion{served: version.Served}
+	if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		return schema, nil
+	}
+
+	var internal apiextensions.JSONSchemaProps
+	if err := extensionv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, &internal, nil); err != nil {
+		return nil, err
+	}
+	structural, err := structuralschema.NewStructural(&internal)
+	if err != nil {
+		return nil, err
+	}
+	validator, _, err := apiservervalidation.NewSchemaValidator(&internal)
+	if err != nil {
+		return nil, err
+	}
+	schema.structural = structural
+	schema.validator = validator
+	schema.cel = cel.NewValidator(structural, true, celconfig.PerCallLimit)
+	return schema, nil
+}
+
+// Validate checks every custom resource in the YAML or JSON documents of `manifest`. Documents that aren't custom
+// resources of the API groups defined by the CRDs, such as ConfigMaps, are skipped. Calling this function will fully
+// read and close the manifest.
+func (v *Validator) Validate(manifest io.ReadCloser) (*ValidationResult, error) {
+	objects, err := readManifests([]io.ReadCloser{manifest})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ValidationResult{}
+	for _, object := range objects {
+		apiVersion, _ := object["apiVersion"].(string)
+		kind, _ := object["kind"].(string)
+		metadata, _ := object["metadata"].(map[string]any)
+		name, _ := metadata["name"].(string)
+		if namespace, _ := metadata["namespace"].(string); namespace != "" {
+			name = namespace + "/" + name
+		}
+		resource := strings.TrimSpace(kind + " " + name)
+
+		group, _, _ := strings.Cut(apiVersion, "/")
+		schema, ok := v.versions[apiVersion+"/"+kind]
+		if !ok && !v.groups[group] {
+			result.Skipped = append(result.Skipped, strings.TrimSpace(apiVersion+" "+resource))
+			continue
+		}
+
+		result.Resources++
+		switch {
+		case !ok:
+			result.Errors = append(result.Errors, ValidationError{
+				Resource: resource, Path: ".apiVersion", Message: fmt.Sprintf("no CRD defines %s %s", apiVersion, kind),
+			})
+		case !schema.served:
+			result.Errors = append(result.Errors, ValidationError{
+				Resource: resource, Path: ".apiVersion", Message: fmt.Sprintf("version %s is not served", apiVersion),
+			})
+		default:
+			// The API server decodes whole numbers as int64s, which the CEL rules expect for integers.
+			if data, err := json.Marshal(object); err == nil {
+				_ = utiljson.Unmarshal(data, &object)
+			}
+			for _, err := range schema.validate(object) {
+				err.Resource = resource
+				result.Errors = append(result.Errors, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// validate returns the problems with `object`, which is modified by pruning and defaulting like the API server does.
+// The namespace of cluster-scoped objects is reported too, although the API server drops it instead.
+func (s *validationVersion) validate(object map[string]any) []ValidationError {
+	_, _, unknown, err := objectmeta.GetObjectMetaWithOptions(object, objectmeta.ObjectMetaOptions{ReturnUnknownFieldPaths: true})
+	if err != nil {
+		return []ValidationError{{Path: ".metadata", Message: err.Error()}}
+	}
+
+	var errs field.ErrorList
+	if s.structural != nil {
+		unknown = append(unknown, pruning.PruneWithOptions(object, s.structural, true, structuralschema.UnknownFieldPathOptions{
+			TrackUnknownFieldPaths: true,
+		})...)
+		defaulting.PruneNonNullableNullsWithoutDefaults(object, s.structural)
+		fieldErr, paths := objectmeta.CoerceWithOptions(nil, object, s.structural, false, objectmeta.CoerceOptions{ReturnUnknownFieldPaths: true})
+		if fieldErr != nil {
+			errs = append(errs, fieldErr)
+		}
+		unknown = append(unknown, paths...)
+		defaulting.Default(object, s.structural)
+
+		errs = append(errs, apiservervalidation.ValidateCustomResource(nil, object, s.validator)...)
+		if s.cel != nil {
+			// There is no old object, so transition rules that use oldSelf are skipped, like on create.
+			celErrs, _ := s.cel.Validate(context.Background(), nil, s.structural, object, nil, celconfig.RuntimeCELCostBudget)
+			errs = append(errs, celErrs...)
+		}
+		errs = append(errs, objectmeta.Validate(nil, object, s.structural, false)...)
+		errs = append(errs, listtype.ValidateListSetsAndMaps(nil, s.structural, object)...)
+	}
+
+	metadata, _ := object["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	generateName, _ := metadata["generateName"].(string)
+	switch {
+	case name == "" && generateName == "":
+		errs = append(errs, field.Required(field.NewPath("metadata", "name"), "name or generateName is required"))
+	case name != "":
+		for _, msg := range apimachineryvalidation.NameIsDNSSubdomain(name, false) {
+			errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), name, msg))
+		}
+	}
+	if namespace, _ := metadata["namespace"].(string); namespace != "" && s.clusterScoped {
+		kind, _ := object["kind"].(string)
+		errs = append(errs, field.Forbidden(field.NewPath("metadata", "namespace"), clusterScopedNamespaceError(kind)))
+	}
+
+	result := make([]ValidationError, 0, len(unknown)+len(errs))
+	for _, path := range unknown {
+		result = append(result, ValidationError{Path: "." + path, Message: "unknown field"})
+	}
+	for _, err := range errs {
+		result = append(result, ValidationError{Path: "." + err.Field, Message: err.ErrorBody()})
+	}
+	// The schema validator walks properties in map order, so sort for stable output.
+	slices.SortStableFunc(result, func(a, b ValidationError) int { return strings.Compare(a.Path, b.Path) })
+	return result
+}
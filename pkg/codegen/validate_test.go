@@ -0,0 +1,251 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const validateTestCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+spec:
+  group: stable.example.com
+  names:
+    kind: CronTab
+    plural: crontabs
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              required: [cronSpec]
+              x-kubernetes-validations:
+                - rule: '!has(self.maxReplicas) || self.replicas <= self.maxReplicas'
+                  message: replicas must not exceed maxReplicas
+              properties:
+                cronSpec:
+                  type: string
+                  pattern: '^(\S+\s){4}\S+$'
+                replicas:
+                  type: integer
+                  minimum: 1
+                  default: 1
+                maxReplicas:
+                  type: integer
+                policy:
+                  type: string
+                  enum: [Allow, Forbid]
+                args:
+                  type: array
+                  x-kubernetes-list-type: set
+                  items:
+                    type: string
+    - name: v1beta1
+      served: false
+      storage: false
+      schema:
+        openAPIV3Schema:
+          type: object
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: cronpolicies.stable.example.com
+spec:
+  group: stable.example.com
+  names:
+    kind: CronPolicy
+    plural: cronpolicies
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              x-kubernetes-preserve-unknown-fields: true
+`
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+	v, err := NewValidator([]io.ReadCloser{io.NopCloser(strings.NewReader(validateTestCRD))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return v
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+	}{
+		{
+			name: "valid",
+			manifest: `apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: my-crontab
+  namespace: jobs
+spec:
+  cronSpec: "*/5 * * * *"
+  policy: Forbid
+  args: [a, b]
+`,
+		},
+		{
+			name: "invalid",
+			manifest: `apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: my-crontab
+  namespace: jobs
+spec:
+  cronSpec: "every day"
+  replicas: 0
+  policy: Sometimes
+  args: [a, a]
+  image: cron
+`,
+			want: []string{
+				`CronTab jobs/my-crontab: .spec.args[1]: Duplicate value: "a"`,
+				`CronTab jobs/my-crontab: .spec.cronSpec: Invalid value: "every day": spec.cronSpec in body should match '^(\S+\s){4}\S+$'`,
+				"CronTab jobs/my-crontab: .spec.image: unknown field",
+				`CronTab jobs/my-crontab: .spec.policy: Unsupported value: "Sometimes": supported values: "Allow", "Forbid"`,
+				"CronTab jobs/my-crontab: .spec.replicas: Invalid value: 0: spec.replicas in body should be greater than or equal to 1",
+			},
+		},
+		{
+			name: "wrong types and missing fields",
+			manifest: `apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  labels:
+    app: cron
+spec:
+  replicas: two
+`,
+			want: []string{
+				`CronTab: .metadata.name: Required value: name or generateName is required`,
+				`CronTab: .spec.cronSpec: Required value`,
+				`CronTab: .spec.replicas: Invalid value: "string": spec.replicas in body must be of type integer: "string"`,
+			},
+		},
+		{
+			name: "cel rules",
+			manifest: `apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: my-crontab
+spec:
+  cronSpec: "*/5 * * * *"
+  replicas: 3
+  maxReplicas: 2
+`,
+			want: []string{
+				"CronTab my-crontab: .spec: Invalid value: replicas must not exceed maxReplicas",
+			},
+		},
+		{
+			name: "cluster-scoped",
+			manifest: `apiVersion: stable.example.com/v1
+kind: CronPolicy
+metadata:
+  name: nightly
+  namespace: jobs
+---
+apiVersion: stable.example.com/v1
+kind: CronPolicy
+metadata:
+  name: weekly
+`,
+			want: []string{
+				"CronPolicy jobs/nightly: .metadata.namespace: Forbidden: must not be set, CronPolicy is cluster-scoped",
+			},
+		},
+		{
+			name: "unknown versions",
+			manifest: `apiVersion: stable.example.com/v1beta1
+kind: CronTab
+metadata:
+  name: old
+---
+apiVersion: stable.example.com/v2
+kind: CronTab
+metadata:
+  name: new
+`,
+			want: []string{
+				"CronTab old: .apiVersion: version stable.example.com/v1beta1 is not served",
+				"CronTab new: .apiVersion: no CRD defines stable.example.com/v2 CronTab",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestValidator(t).Validate(io.NopCloser(strings.NewReader(tt.manifest)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, err := range result.Errors {
+				got = append(got, err.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("expected errors:\n%s\ngot:\n%s", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateSkipped(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: my-crontab
+spec:
+  cronSpec: "*/5 * * * *"
+`
+	result, err := newTestValidator(t).Validate(io.NopCloser(strings.NewReader(manifest)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Resources != 1 || len(result.Errors) != 0 {
+		t.Errorf("expected 1 valid resource, got %d with errors %v", result.Resources, result.Errors)
+	}
+	if want := "v1 ConfigMap settings"; len(result.Skipped) != 1 || result.Skipped[0] != want {
+		t.Errorf("expected %q to be skipped, got %q", want, result.Skipped)
+	}
+}
@@ -0,0 +1,703 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
+	extensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// The paths of the client-side validation helpers, relative to the root of the generated package.
+const (
+	nodejsValidationPath = "validation.ts"
+	pythonValidationPath = "validation.py"
+	goValidationPath     = "validation/validation.go"
+)
+
+// validationSchema is the part of a CRD schema that the client-side validation helpers check. It is embedded in the
+// helpers as JSON, keyed by apiVersion and kind.
+type validationSchema struct {
+	Minimum              *float64                     `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool                         `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64                     `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool                         `json:"exclusiveMaximum,omitempty"`
+	MultipleOf           *float64                     `json:"multipleOf,omitempty"`
+	MinLength            *int64                       `json:"minLength,omitempty"`
+	MaxLength            *int64                       `json:"maxLength,omitempty"`
+	Pattern              string                       `json:"pattern,omitempty"`
+	MinItems             *int64                       `json:"minItems,omitempty"`
+	MaxItems             *int64                       `json:"maxItems,omitempty"`
+	MinProperties        *int64                       `json:"minProperties,omitempty"`
+	MaxProperties        *int64                       `json:"maxProperties,omitempty"`
+	Properties           map[string]*validationSchema `json:"properties,omitempty"`
+	Items                *validationSchema            `json:"items,omitempty"`
+	AdditionalProperties *validationSchema            `json:"additionalProperties,omitempty"`
+	// ClusterScoped is only set on the schemas of cluster-scoped kinds, whose namespace must not be set.
+	ClusterScoped bool `json:"clusterScoped,omitempty"`
+}
+
+// newValidationSchema returns the constraints of `schema` and its nested schemas, with the properties named by
+// `name`, or nil if there are none. Top-level properties in `skip` aren't checked.
+func newValidationSchema(schema extensionv1.JSONSchemaProps, name func(string) string, skip ...string) *validationSchema {
+	s := &validationSchema{
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		MultipleOf:       schema.MultipleOf,
+		MinLength:        schema.MinLength,
+		MaxLength:        schema.MaxLength,
+		Pattern:          schema.Pattern,
+		MinItems:         schema.MinItems,
+		MaxItems:         schema.MaxItems,
+		MinProperties:    schema.MinProperties,
+		MaxProperties:    schema.MaxProperties,
+	}
+	for property, propertySchema := range schema.Properties {
+		if slices.Contains(skip, property) {
+			continue
+		}
+		if p := newValidationSchema(propertySchema, name); p != nil {
+			if s.Properties == nil {
+				s.Properties = map[string]*validationSchema{}
+			}
+			s.Properties[name(property)] = p
+		}
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		s.Items = newValidationSchema(*schema.Items.Schema, name)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		s.AdditionalProperties = newValidationSchema(*schema.AdditionalProperties.Schema, name)
+	}
+	if s.isEmpty() {
+		return nil
+	}
+	return s
+}
+
+// isEmpty returns true if `s` has no constraints. The exclusive flags only qualify the minimum and maximum.
+func (s *validationSchema) isEmpty() bool {
+	return s.Minimum == nil && s.Maximum == nil && s.MultipleOf == nil && s.MinLength == nil && s.MaxLength == nil &&
+		s.Pattern == "" && s.MinItems == nil && s.MaxItems == nil && s.MinProperties == nil && s.MaxProperties == nil &&
+		s.Properties == nil && s.Items == nil && s.AdditionalProperties == nil
+}
+
+// validationSchemas returns the constraints of every generated CRD version as indented JSON, keyed by apiVersion and
+// kind, with the properties named by `name`. metadata is checked by the API server's own rules, so it's skipped, except
+// for the namespace of cluster-scoped kinds, which the API server silently drops.
+func (pg *PackageGenerator) validationSchemas(name func(string) string) ([]byte, error) {
+	schemas := map[string]map[string]*validationSchema{}
+	for _, crg := range pg.CustomResourceGenerators {
+		crd := crg.CustomResourceDefinition
+		for _, v := range crd.Spec.Versions {
+			if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			s := newValidationSchema(*v.Schema.OpenAPIV3Schema, name, "apiVersion", "kind", "metadata")
+			if crg.IsClusterScoped() {
+				if s == nil {
+					s = &validationSchema{}
+				}
+				s.ClusterScoped = true
+			}
+			if s == nil {
+				continue
+			}
+			apiVersion := crd.Spec.Group + "/" + v.Name
+			if schemas[apiVersion] == nil {
+				schemas[apiVersion] = map[string]*validationSchema{}
+			}
+			schemas[apiVersion][crd.Spec.Names.Kind] = s
+		}
+	}
+	return json.MarshalIndent(schemas, "", "  ")
+}
+
+// addValidationHelpers adds the client-side validation helper of `language` to the generated `files`, in the
+// validation module of the package in `packageDir`. Returns an error if an API group is generated into a module of the
+// same name.
+func addValidationHelpers(pg *PackageGenerator, language, packageDir string, files map[string][]byte) error {
+	var helperPath string
+	var name func(string) string
+	var template string
+	switch language {
+	case NodeJS:
+		helperPath, name, template = nodejsValidationPath, identity, nodejsValidationFile
+	case Python:
+		helperPath, name, template = path.Join(packageDir, pythonValidationPath), python.InitParamName, pythonValidationFile
+	case Go:
+		helperPath, name, template = goValidationPath, identity, goValidationFile
+	default:
+		return fmt.Errorf("validation helpers are not supported for %s", language)
+	}
+	module := path.Join(packageDir, "validation") + "/"
+	for file := range files {
+		if file == helperPath || strings.HasPrefix(file, module) {
+			return fmt.Errorf("cannot add validation helpers: %s is in the validation module, generate its API group into another module with --group-module", file)
+		}
+	}
+
+	schemas, err := pg.validationSchemas(name)
+	if err != nil {
+		return fmt.Errorf("could not marshal validation constraints: %w", err)
+	}
+	if language == Go {
+		// Patterns may contain backquotes, which can't be in a raw string literal.
+		if bytes.ContainsRune(schemas, '`') {
+			schemas = []byte(strconv.Quote(string(schemas)))
+		} else {
+			schemas = []byte("`" + string(schemas) + "`")
+		}
+	}
+	files[helperPath] = []byte(strings.Replace(template, "{{schemas}}", string(schemas), 1))
+
+	if language == NodeJS {
+		return addTsconfigFile(files, nodejsValidationPath)
+	}
+	return nil
+}
+
+// addTsconfigFile adds `file` to the files compiled by the generated tsconfig.json, if there is one.
+func addTsconfigFile(files map[string][]byte, file string) error {
+	data, ok := files["tsconfig.json"]
+	if !ok {
+		return nil
+	}
+	var tsconfig map[string]any
+	if err := json.Unmarshal(data, &tsconfig); err != nil {
+		return fmt.Errorf("could not parse tsconfig.json: %w", err)
+	}
+	tsFiles, _ := tsconfig["files"].([]any)
+	tsconfig["files"] = append(tsFiles, file)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(tsconfig); err != nil {
+		return fmt.Errorf("could not write tsconfig.json: %w", err)
+	}
+	files["tsconfig.json"] = buf.Bytes()
+	return nil
+}
+
+func identity(name string) string {
+	return name
+}
+
+const nodejsValidationFile = `// *** WARNING: this file was generated by crd2pulumi. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import * as pulumi from "@pulumi/pulumi";
+
+interface Schema {
+    minimum?: number;
+    exclusiveMinimum?: boolean;
+    maximum?: number;
+    exclusiveMaximum?: boolean;
+    multipleOf?: number;
+    minLength?: number;
+    maxLength?: number;
+    pattern?: string;
+    minItems?: number;
+    maxItems?: number;
+    minProperties?: number;
+    maxProperties?: number;
+    properties?: Record<string, Schema>;
+    items?: Schema;
+    additionalProperties?: Schema;
+    clusterScoped?: boolean;
+}
+
+const schemas: Record<string, Record<string, Schema>> = {{schemas}};
+
+/**
+ * Checks the arguments of a custom resource of the given apiVersion and kind against the validation constraints of
+ * its CRD, such as minimum, maxLength and pattern, and returns every violation. A namespace set on a cluster-scoped
+ * kind is a violation too. Values that aren't known yet, such as outputs of other resources, aren't checked.
+ */
+export function validate(apiVersion: string, kind: string, args: any): string[] {
+    const schema = schemas[apiVersion]?.[kind];
+    const errors: string[] = [];
+    if (schema !== undefined) {
+        const namespace = args?.metadata?.namespace;
+        if (schema.clusterScoped && typeof namespace === "string" && namespace !== "") {
+            errors.push(".metadata.namespace: must not be set, " + kind + " is cluster-scoped");
+        }
+        checkValue(schema, args, "", errors);
+    }
+    return errors;
+}
+
+/**
+ * Like validate, but throws an error listing every violation.
+ */
+export function check(apiVersion: string, kind: string, args: any): void {
+    const errors = validate(apiVersion, kind, args);
+    if (errors.length > 0) {
+        throw new Error("invalid " + kind + ":\n  " + errors.join("\n  "));
+    }
+}
+
+function checkValue(schema: Schema, value: any, path: string, errors: string[]): void {
+    if (value === undefined || value === null || pulumi.Output.isInstance(value) || typeof value.then === "function") {
+        return;
+    }
+    const fail = (message: string) => errors.push((path || ".") + ": " + message);
+
+    if (typeof value === "number") {
+        if (schema.minimum !== undefined) {
+            if (schema.exclusiveMinimum && value <= schema.minimum) {
+                fail("must be greater than " + schema.minimum);
+            } else if (value < schema.minimum) {
+                fail("must be at least " + schema.minimum);
+            }
+        }
+        if (schema.maximum !== undefined) {
+            if (schema.exclusiveMaximum && value >= schema.maximum) {
+                fail("must be less than " + schema.maximum);
+            } else if (value > schema.maximum) {
+                fail("must be at most " + schema.maximum);
+            }
+        }
+        if (schema.multipleOf !== undefined && !Number.isInteger(value / schema.multipleOf)) {
+            fail("must be a multiple of " + schema.multipleOf);
+        }
+    } else if (typeof value === "string") {
+        const length = [...value].length;
+        if (schema.minLength !== undefined && length < schema.minLength) {
+            fail("must be at least " + schema.minLength + " characters long");
+        }
+        if (schema.maxLength !== undefined && length > schema.maxLength) {
+            fail("must be at most " + schema.maxLength + " characters long");
+        }
+        if (schema.pattern !== undefined && !matches(schema.pattern, value)) {
+            fail("must match " + schema.pattern);
+        }
+    } else if (Array.isArray(value)) {
+        if (schema.minItems !== undefined && value.length < schema.minItems) {
+            fail("must have at least " + schema.minItems + " items");
+        }
+        if (schema.maxItems !== undefined && value.length > schema.maxItems) {
+            fail("must have at most " + schema.maxItems + " items");
+        }
+        value.forEach((item, i) => {
+            if (schema.items !== undefined) {
+                checkValue(schema.items, item, path + "[" + i + "]", errors);
+            }
+        });
+    } else if (typeof value === "object") {
+        const keys = Object.keys(value);
+        if (schema.minProperties !== undefined && keys.length < schema.minProperties) {
+            fail("must have at least " + schema.minProperties + " properties");
+        }
+        if (schema.maxProperties !== undefined && keys.length > schema.maxProperties) {
+            fail("must have at most " + schema.maxProperties + " properties");
+        }
+        const properties = schema.properties ?? {};
+        for (const name of Object.keys(properties)) {
+            checkValue(properties[name], value[name], path + "." + name, errors);
+        }
+        for (const key of keys) {
+            if (schema.additionalProperties !== undefined) {
+                checkValue(schema.additionalProperties, value[key], path + "." + key, errors);
+            }
+        }
+    }
+}
+
+// matches returns true if value matches pattern. The API server uses Go regular expressions, so patterns that are not
+// valid JavaScript regular expressions are not checked.
+function matches(pattern: string, value: string): boolean {
+    try {
+        return new RegExp(pattern, "u").test(value);
+    } catch {
+        return true;
+    }
+}
+`
+
+const pythonValidationFile = `# coding=utf-8
+# *** WARNING: this file was generated by crd2pulumi. ***
+# *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+import inspect
+import json
+import re
+from typing import Any, List, Mapping
+
+import pulumi
+
+_SCHEMAS = json.loads(r"""{{schemas}}""")
+
+
+def validate(api_version: str, kind: str, args: Any) -> List[str]:
+    """
+    Checks the arguments of a custom resource of the given apiVersion and kind against the validation constraints of
+    its CRD, such as minimum, maxLength and pattern, and returns every violation. A namespace set on a cluster-scoped
+    kind is a violation too. The arguments are a dict of the resource's keyword arguments, or its Args class. Values
+    that aren't known yet, such as outputs of other resources, aren't checked.
+    """
+    errors: List[str] = []
+    schema = _SCHEMAS.get(api_version, {}).get(kind)
+    if schema is not None:
+        if schema.get("clusterScoped"):
+            namespace = _field(_field(args, "metadata"), "namespace")
+            if isinstance(namespace, str) and namespace:
+                errors.append(f".metadata.namespace: must not be set, {kind} is cluster-scoped")
+        _check(schema, args, "", errors)
+    return errors
+
+
+def check(api_version: str, kind: str, args: Any) -> None:
+    """
+    Like validate, but raises a ValueError listing every violation.
+    """
+    errors = validate(api_version, kind, args)
+    if errors:
+        raise ValueError(f"invalid {kind}:\n  " + "\n  ".join(errors))
+
+
+def _check(schema: Mapping[str, Any], value: Any, path: str, errors: List[str]) -> None:
+    if value is None or isinstance(value, pulumi.Output) or inspect.isawaitable(value):
+        return
+
+    def fail(message: str) -> None:
+        errors.append(f"{path or '.'}: {message}")
+
+    if isinstance(value, (int, float)) and not isinstance(value, bool):
+        if "minimum" in schema:
+            if schema.get("exclusiveMinimum") and value <= schema["minimum"]:
+                fail(f"must be greater than {schema['minimum']}")
+            elif value < schema["minimum"]:
+                fail(f"must be at least {schema['minimum']}")
+        if "maximum" in schema:
+            if schema.get("exclusiveMaximum") and value >= schema["maximum"]:
+                fail(f"must be less than {schema['maximum']}")
+            elif value > schema["maximum"]:
+                fail(f"must be at most {schema['maximum']}")
+        if "multipleOf" in schema and (value / schema["multipleOf"]) % 1 != 0:
+            fail(f"must be a multiple of {schema['multipleOf']}")
+    elif isinstance(value, str):
+        if "minLength" in schema and len(value) < schema["minLength"]:
+            fail(f"must be at least {schema['minLength']} characters long")
+        if "maxLength" in schema and len(value) > schema["maxLength"]:
+            fail(f"must be at most {schema['maxLength']} characters long")
+        if "pattern" in schema and not _matches(schema["pattern"], value):
+            fail(f"must match {schema['pattern']}")
+    elif isinstance(value, (list, tuple)):
+        if "minItems" in schema and len(value) < schema["minItems"]:
+            fail(f"must have at least {schema['minItems']} items")
+        if "maxItems" in schema and len(value) > schema["maxItems"]:
+            fail(f"must have at most {schema['maxItems']} items")
+        if "items" in schema:
+            for i, item in enumerate(value):
+                _check(schema["items"], item, f"{path}[{i}]", errors)
+    elif isinstance(value, Mapping):
+        if "minProperties" in schema and len(value) < schema["minProperties"]:
+            fail(f"must have at least {schema['minProperties']} properties")
+        if "maxProperties" in schema and len(value) > schema["maxProperties"]:
+            fail(f"must have at most {schema['maxProperties']} properties")
+        for name, prop in schema.get("properties", {}).items():
+            _check(prop, value.get(name), f"{path}.{name}", errors)
+        if "additionalProperties" in schema:
+            for key, item in value.items():
+                _check(schema["additionalProperties"], item, f"{path}.{key}", errors)
+    else:
+        # Args classes expose their properties as attributes.
+        for name, prop in schema.get("properties", {}).items():
+            _check(prop, getattr(value, name, None), f"{path}.{name}", errors)
+
+
+def _field(value: Any, name: str) -> Any:
+    # Objects are dicts or input type classes, which expose their properties as attributes.
+    if isinstance(value, Mapping):
+        return value.get(name)
+    return getattr(value, name, None)
+
+
+def _matches(pattern: str, value: str) -> bool:
+    # The API server uses Go regular expressions, so patterns that aren't valid Python regular expressions aren't
+    # checked.
+    try:
+        return re.search(pattern, value) is not None
+    except re.error:
+        return True
+`
+
+const goValidationFile = `// Code generated by crd2pulumi DO NOT EDIT.
+// *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
+
+// Package validation checks custom resources against the validation constraints of their CRDs, such as minimum,
+// maxLength and pattern, before they are sent to the API server.
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+type schema struct {
+	Minimum              *float64           ` + "`json:\"minimum\"`" + `
+	ExclusiveMinimum     bool               ` + "`json:\"exclusiveMinimum\"`" + `
+	Maximum              *float64           ` + "`json:\"maximum\"`" + `
+	ExclusiveMaximum     bool               ` + "`json:\"exclusiveMaximum\"`" + `
+	MultipleOf           *float64           ` + "`json:\"multipleOf\"`" + `
+	MinLength            *int               ` + "`json:\"minLength\"`" + `
+	MaxLength            *int               ` + "`json:\"maxLength\"`" + `
+	Pattern              string             ` + "`json:\"pattern\"`" + `
+	MinItems             *int               ` + "`json:\"minItems\"`" + `
+	MaxItems             *int               ` + "`json:\"maxItems\"`" + `
+	MinProperties        *int               ` + "`json:\"minProperties\"`" + `
+	MaxProperties        *int               ` + "`json:\"maxProperties\"`" + `
+	Properties           map[string]*schema ` + "`json:\"properties\"`" + `
+	Items                *schema            ` + "`json:\"items\"`" + `
+	AdditionalProperties *schema            ` + "`json:\"additionalProperties\"`" + `
+	ClusterScoped        bool               ` + "`json:\"clusterScoped\"`" + `
+}
+
+var schemas map[string]map[string]*schema
+
+func init() {
+	if err := json.Unmarshal([]byte(schemasJSON), &schemas); err != nil {
+		panic(err)
+	}
+}
+
+// Validate checks a custom resource of the given apiVersion and kind against the validation constraints of its CRD
+// and returns every violation. A namespace set on a cluster-scoped kind is a violation too. The object is either the
+// resource's Args, or plain values such as those decoded from YAML or JSON. Values that aren't known yet, such as
+// outputs of other resources, aren't checked.
+func Validate(apiVersion, kind string, object any) []error {
+	var errs []error
+	if s := schemas[apiVersion][kind]; s != nil {
+		object, _ := plainValue(reflect.ValueOf(object)).(map[string]any)
+		metadata, _ := object["metadata"].(map[string]any)
+		if namespace, _ := metadata["namespace"].(string); s.ClusterScoped && namespace != "" {
+			errs = append(errs, fmt.Errorf(".metadata.namespace: must not be set, %s is cluster-scoped", kind))
+		}
+		s.check(object, "", &errs)
+	}
+	return errs
+}
+
+func (s *schema) check(value any, path string, errs *[]error) {
+	fail := func(format string, args ...any) {
+		p := path
+		if p == "" {
+			p = "."
+		}
+		*errs = append(*errs, fmt.Errorf("%s: %s", p, fmt.Sprintf(format, args...)))
+	}
+
+	switch v := value.(type) {
+	case nil:
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			// Patterns are Go regular expressions, like the API server's.
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(v) {
+				fail("must match %s", s.Pattern)
+			}
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.check(item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case map[string]any:
+		if s.MinProperties != nil && len(v) < *s.MinProperties {
+			fail("must have at least %d properties", *s.MinProperties)
+		}
+		if s.MaxProperties != nil && len(v) > *s.MaxProperties {
+			fail("must have at most %d properties", *s.MaxProperties)
+		}
+		for _, name := range sortedKeys(s.Properties) {
+			s.Properties[name].check(v[name], path+"."+name, errs)
+		}
+		if s.AdditionalProperties != nil {
+			for _, key := range sortedKeys(v) {
+				s.AdditionalProperties.check(v[key], path+"."+key, errs)
+			}
+		}
+	default:
+		n, ok := number(v)
+		if !ok {
+			return
+		}
+		if s.Minimum != nil {
+			if s.ExclusiveMinimum && n <= *s.Minimum {
+				fail("must be greater than %v", *s.Minimum)
+			} else if n < *s.Minimum {
+				fail("must be at least %v", *s.Minimum)
+			}
+		}
+		if s.Maximum != nil {
+			if s.ExclusiveMaximum && n >= *s.Maximum {
+				fail("must be less than %v", *s.Maximum)
+			} else if n > *s.Maximum {
+				fail("must be at most %v", *s.Maximum)
+			}
+		}
+		if s.MultipleOf != nil {
+			if q := n / *s.MultipleOf; q != math.Trunc(q) {
+				fail("must be a multiple of %v", *s.MultipleOf)
+			}
+		}
+	}
+}
+
+var (
+	outputType     = reflect.TypeOf((*pulumi.Output)(nil)).Elem()
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+)
+
+// plainValue returns the plain values of an Args struct or input, with structs as maps keyed by the property names
+// in their pulumi tags. Outputs aren't known yet, so they are nil.
+func plainValue(v reflect.Value) any {
+	if !v.IsValid() || v.Type().Implements(outputType) {
+		return nil
+	}
+	if v.Type() == jsonNumberType {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return plainValue(v.Elem())
+	case reflect.Struct:
+		// The Args of resources have no tags, unlike the plain structs they stand for.
+		var elementType reflect.Type
+		if v.CanInterface() {
+			if e, ok := v.Interface().(interface{ ElementType() reflect.Type }); ok {
+				elementType = e.ElementType()
+				for elementType.Kind() == reflect.Pointer {
+					elementType = elementType.Elem()
+				}
+			}
+		}
+		object := map[string]any{}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Tag.Get("pulumi")
+			if name == "" && elementType != nil && elementType.Kind() == reflect.Struct {
+				if f, ok := elementType.FieldByName(field.Name); ok {
+					name = f.Tag.Get("pulumi")
+				}
+			}
+			if name == "" {
+				continue
+			}
+			if value := plainValue(v.Field(i)); value != nil {
+				object[name] = value
+			}
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = plainValue(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		object := map[string]any{}
+		for iter := v.MapRange(); iter.Next(); {
+			object[iter.Key().String()] = plainValue(iter.Value())
+		}
+		return object
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return nil
+}
+
+func number(value any) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+const schemasJSON = {{schemas}}
+`
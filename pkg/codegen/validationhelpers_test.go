@@ -0,0 +1,117 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"go/format"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
+)
+
+func newValidationHelpersTestPackageGenerator(t *testing.T) *PackageGenerator {
+	t.Helper()
+	pg, err := ReadPackagesFromSource("0.0.0-dev", []io.ReadCloser{io.NopCloser(strings.NewReader(validateTestCRD))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return pg
+}
+
+func TestValidationSchemas(t *testing.T) {
+	pg := newValidationHelpersTestPackageGenerator(t)
+
+	schemas, err := pg.validationSchemas(python.InitParamName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{
+  "stable.example.com/v1": {
+    "CronPolicy": {
+      "clusterScoped": true
+    },
+    "CronTab": {
+      "properties": {
+        "spec": {
+          "properties": {
+            "cron_spec": {
+              "pattern": "^(\\S+\\s){4}\\S+$"
+            },
+            "replicas": {
+              "minimum": 1
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+	if string(schemas) != want {
+		t.Errorf("expected schemas:\n%s\ngot:\n%s", want, schemas)
+	}
+}
+
+func TestAddValidationHelpers(t *testing.T) {
+	pg := newValidationHelpersTestPackageGenerator(t)
+
+	files := map[string][]byte{
+		"tsconfig.json": []byte(`{"compilerOptions": {"strict": true}, "files": ["index.ts"]}`),
+	}
+	if err := addValidationHelpers(pg, NodeJS, "", files); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(files[nodejsValidationPath]), `"minimum": 1`) {
+		t.Errorf("expected the constraints in %s, got:\n%s", nodejsValidationPath, files[nodejsValidationPath])
+	}
+	wantTsconfig := `{
+    "compilerOptions": {
+        "strict": true
+    },
+    "files": [
+        "index.ts",
+        "validation.ts"
+    ]
+}
+`
+	if string(files["tsconfig.json"]) != wantTsconfig {
+		t.Errorf("expected tsconfig.json:\n%s\ngot:\n%s", wantTsconfig, files["tsconfig.json"])
+	}
+
+	files = map[string][]byte{}
+	if err := addValidationHelpers(pg, Python, "pulumi_crds", files); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(files["pulumi_crds/validation.py"]), `"cron_spec": {`) {
+		t.Errorf("expected the constraints with Python names, got:\n%s", files["pulumi_crds/validation.py"])
+	}
+
+	files = map[string][]byte{}
+	if err := addValidationHelpers(pg, Go, "", files); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := format.Source(files[goValidationPath]); err != nil {
+		t.Errorf("expected valid Go code, got %v:\n%s", err, files[goValidationPath])
+	}
+	if !strings.Contains(string(files[goValidationPath]), `field.Tag.Get("pulumi")`) {
+		t.Errorf("expected the Go helper to read the Args of resources by their pulumi tags")
+	}
+
+	files = map[string][]byte{"validation/v1/cronTab.go": nil}
+	if err := addValidationHelpers(pg, Go, "", files); err == nil {
+		t.Errorf("expected an error for the validation module of an API group")
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// YAMLSchemaFileName is the name of the JSON Schema for Pulumi YAML programs written by the yaml target.
+const YAMLSchemaFileName = "pulumi-yaml.schema.json"
+
+// yamlExpressionDef is the JSON Schema of a Pulumi YAML expression, which can be used in place of any value: an
+// interpolated string such as ${cert.metadata.name}, or a function call such as fn::readFile.
+var yamlExpressionDef = map[string]any{
+	"anyOf": []any{
+		map[string]any{"type": String, "pattern": `\$\{.+\}`},
+		map[string]any{
+			"type":          Object,
+			"minProperties": 1,
+			"maxProperties": 1,
+			"propertyNames": map[string]any{"pattern": "^fn::"},
+		},
+	},
+}
+
+// GenerateYAML returns the files that Pulumi YAML programs need to use the generated resources: the Pulumi package
+// schema as `schema.json`, and a JSON Schema for Pulumi YAML programs as YAMLSchemaFileName, so editors can validate
+// and autocomplete the type and properties of every resource.
+func GenerateYAML(pg *PackageGenerator, cs *CodegenSettings) (map[string]*bytes.Buffer, error) {
+	files, err := GenerateSchema(pg, &CodegenSettings{Language: Schema, SchemaFormat: SchemaFormatJSON})
+	if err != nil {
+		return nil, err
+	}
+
+	pkgSpec, err := pg.PackageSpec()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Pulumi package spec: %w", err)
+	}
+	data, err := json.MarshalIndent(yamlSchema(pkgSpec, cs.PackageName), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal Pulumi YAML schema: %w", err)
+	}
+	files[YAMLSchemaFileName] = bytes.NewBuffer(append(data, '\n'))
+	return files, nil
+}
+
+// yamlSchema returns a JSON Schema for Pulumi YAML programs using the resources in `pkgSpec`. Resource blocks whose
+// `type` is one of the resource tokens have their `properties` checked against the resource's input properties. Other
+// resource blocks and the rest of the program are left alone, since they may use other packages.
+func yamlSchema(pkgSpec *pschema.PackageSpec, name string) map[string]any {
+	defs := map[string]any{"expression": yamlExpressionDef}
+	for token, typ := range pkgSpec.Types {
+		defs[token] = yamlTypeDef(typ, pkgSpec.Types)
+	}
+
+	tokens := slices.Sorted(maps.Keys(pkgSpec.Resources))
+	conditions := make([]any, 0, len(tokens))
+	for _, token := range tokens {
+		resource := pkgSpec.Resources[token]
+		def := yamlObjectDef(resource.Description, resource.InputProperties, resource.RequiredInputs, pkgSpec.Types)
+		if resource.DeprecationMessage != "" {
+			def["deprecated"] = true
+		}
+		defs[token] = def
+
+		then := map[string]any{
+			"properties": map[string]any{"properties": yamlRef(token)},
+		}
+		if len(resource.RequiredInputs) > 0 {
+			then["required"] = []string{"properties"}
+		}
+		conditions = append(conditions, map[string]any{
+			"if": map[string]any{
+				"properties": map[string]any{"type": map[string]any{"const": token}},
+				"required":   []string{"type"},
+			},
+			"then": then,
+		})
+	}
+
+	defs["resource"] = map[string]any{
+		"type":     Object,
+		"required": []string{"type"},
+		"properties": map[string]any{
+			"type": map[string]any{
+				"description": "The type token of the resource.",
+				// Any other type is allowed, but the generated ones are suggested.
+				"anyOf": []any{map[string]any{"enum": tokens}, map[string]any{"type": String}},
+			},
+			"properties": map[string]any{"type": Object},
+			"options":    map[string]any{"type": Object},
+		},
+		"allOf": conditions,
+	}
+
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       fmt.Sprintf("Pulumi YAML program using %s", name),
+		"description": "Validates the resources of a Pulumi YAML program that use resources generated by crd2pulumi.",
+		"type":        Object,
+		"properties": map[string]any{
+			"resources": map[string]any{
+				"type":                 Object,
+				"additionalProperties": yamlRef("resource"),
+			},
+		},
+		"$defs": defs,
+	}
+}
+
+// yamlTypeDef returns the JSON Schema of an object or enum type. References to `types` are followed.
+func yamlTypeDef(typ pschema.ComplexTypeSpec, types map[string]pschema.ComplexTypeSpec) map[string]any {
+	if len(typ.Enum) == 0 {
+		return yamlObjectDef(typ.Description, typ.Properties, typ.Required, types)
+	}
+	values := make([]any, 0, len(typ.Enum))
+	for _, value := range typ.Enum {
+		values = append(values, value.Value)
+	}
+	def := map[string]any{"enum": values}
+	if typ.Description != "" {
+		def["description"] = typ.Description
+	}
+	return def
+}
+
+// yamlObjectDef returns the JSON Schema of an object with the given properties. Unknown properties are rejected, to
+// catch typos, unless the object has no known properties at all.
+func yamlObjectDef(
+	description string, properties map[string]pschema.PropertySpec, required []string, types map[string]pschema.ComplexTypeSpec,
+) map[string]any {
+	def := map[string]any{"type": Object}
+	if description != "" {
+		def["description"] = description
+	}
+	if len(properties) == 0 {
+		return def
+	}
+
+	propertySchemas := make(map[string]any, len(properties))
+	for name, property := range properties {
+		schema := yamlValue(property.TypeSpec, types)
+		if property.Description != "" {
+			schema["description"] = property.Description
+		}
+		if property.Default != nil {
+			schema["default"] = property.Default
+		}
+		if property.DeprecationMessage != "" {
+			schema["deprecated"] = true
+		}
+		propertySchemas[name] = schema
+	}
+	def["properties"] = propertySchemas
+	def["additionalProperties"] = false
+	if len(required) > 0 {
+		def["required"] = required
+	}
+	return def
+}
+
+// yamlValue returns the JSON Schema of a value of type `typeSpec`, which may also be an expression.
+func yamlValue(typeSpec pschema.TypeSpec, types map[string]pschema.ComplexTypeSpec) map[string]any {
+	schema := yamlType(typeSpec, types)
+	if typeSpec.Type == String && typeSpec.Ref == "" || len(schema) == 0 {
+		// Strings and values of any type already accept expressions.
+		return schema
+	}
+	return map[string]any{"anyOf": []any{schema, yamlRef("expression")}}
+}
+
+// yamlType returns the JSON Schema of `typeSpec`. References to types missing from `types` accept any value.
+func yamlType(typeSpec pschema.TypeSpec, types map[string]pschema.ComplexTypeSpec) map[string]any {
+	switch {
+	case typeSpec.Items != nil:
+		return map[string]any{"type": Array, "items": yamlValue(*typeSpec.Items, types)}
+	case typeSpec.AdditionalProperties != nil:
+		return map[string]any{"type": Object, "additionalProperties": yamlValue(*typeSpec.AdditionalProperties, types)}
+	case len(typeSpec.OneOf) > 0:
+		anyOf := make([]any, 0, len(typeSpec.OneOf))
+		for _, t := range typeSpec.OneOf {
+			anyOf = append(anyOf, yamlType(t, types))
+		}
+		return map[string]any{"anyOf": anyOf}
+	case typeSpec.Ref == anyTypeRef:
+		return map[string]any{}
+	case strings.HasPrefix(typeSpec.Ref, typeRefPrefix):
+		token := strings.TrimPrefix(typeSpec.Ref, typeRefPrefix)
+		if _, ok := types[token]; !ok {
+			return map[string]any{}
+		}
+		return yamlRef(token)
+	case typeSpec.Type != "":
+		return map[string]any{"type": typeSpec.Type}
+	}
+	return map[string]any{}
+}
+
+// yamlRef returns a reference to the definition `name`, escaped as a JSON pointer.
+func yamlRef(name string) map[string]any {
+	name = strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
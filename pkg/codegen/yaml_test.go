@@ -0,0 +1,164 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGenerateYAML(t *testing.T) {
+	files, err := GenerateYAML(newCronTabTestPackageGenerator(), &CodegenSettings{Language: YAML, PackageName: "crds"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 || files["schema.json"] == nil || files[YAMLSchemaFileName] == nil {
+		t.Fatalf("expected schema.json and %s, got %v", YAMLSchemaFileName, files)
+	}
+
+	schema, err := jsonschema.CompileString(YAMLSchemaFileName, files[YAMLSchemaFileName].String())
+	if err != nil {
+		t.Fatalf("could not compile %s: %v", YAMLSchemaFileName, err)
+	}
+
+	tests := []struct {
+		name    string
+		program string
+		valid   bool
+	}{
+		{
+			name: "valid",
+			program: `
+resources:
+  crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      metadata:
+        name: my-crontab
+      spec:
+        cronSpec: "*/5 * * * *"
+        replicas: 2
+        policy: Forbid
+        args:
+          - value: 1
+          - value: "two"
+`,
+			valid: true,
+		},
+		{
+			name: "expressions",
+			program: `
+resources:
+  crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      spec:
+        cronSpec: ${config.schedule}
+        replicas: ${replicas}
+        policy:
+          fn::readFile: ./policy.txt
+`,
+			valid: true,
+		},
+		{
+			name: "other packages",
+			program: `
+resources:
+  bucket:
+    type: aws:s3:Bucket
+    properties:
+      anything: goes
+`,
+			valid: true,
+		},
+		{
+			name: "missing required property",
+			program: `
+resources:
+  crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      spec:
+        replicas: 2
+`,
+		},
+		{
+			name: "missing required properties",
+			program: `
+resources:
+  crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+`,
+		},
+		{
+			name: "unknown property",
+			program: `
+resources:
+  crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      spec:
+        cronSpec: "*/5 * * * *"
+        replica: 2
+`,
+		},
+		{
+			name: "wrong type",
+			program: `
+resources:
+  crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      spec:
+        cronSpec: "*/5 * * * *"
+        replicas: two
+`,
+		},
+		{
+			name: "invalid enum value",
+			program: `
+resources:
+  crontab:
+    type: kubernetes:stable.example.com/v1:CronTab
+    properties:
+      spec:
+        cronSpec: "*/5 * * * *"
+        policy: Sometimes
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := yaml.YAMLToJSON([]byte(tt.program))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var program any
+			if err := json.Unmarshal(data, &program); err != nil {
+				t.Fatal(err)
+			}
+			err = schema.Validate(program)
+			if tt.valid && err != nil {
+				t.Errorf("expected program to be valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("expected program to be invalid")
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pulumi/crd2pulumi/cmd"
@@ -31,8 +32,8 @@ import (
 
 var languages = []string{"dotnet", "go", "nodejs", "python", "java"}
 
-// execCrd2Pulumi runs the crd2pulumi binary in a temporary directory
-func execCrd2Pulumi(t *testing.T, lang, path string, additionalValidation func(t *testing.T, path string)) {
+// execCrd2Pulumi runs the crd2pulumi binary in a temporary directory, with any additional flags
+func execCrd2Pulumi(t *testing.T, lang, path string, additionalValidation func(t *testing.T, path string), flags ...string) {
 	tmpdir, err := os.MkdirTemp("", "test-crd2pulumi-*")
 	require.NoError(t, err)
 	t.Cleanup(func() {
@@ -45,7 +46,7 @@ func execCrd2Pulumi(t *testing.T, lang, path string, additionalValidation func(t
 
 	cmd := cmd.New()
 	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
-	cmd.SetArgs([]string{langFlag, tmpdir, "--force", path})
+	cmd.SetArgs(append([]string{langFlag, tmpdir, "--force", path}, flags...))
 	cmd.SetOut(stdout)
 	cmd.SetErr(stderr)
 
@@ -79,29 +80,29 @@ func TestCRDsFromFile(t *testing.T) {
 	})
 }
 
-// TestCRDsFromUrl pulls the CRD YAML file from a URL and generates it in each language
-func TestCRDsFromUrl(t *testing.T) {
-	validateNodeCompiles := func(t *testing.T, path string) {
-		withDir(t, path, func() {
-			runRequireNoError(t, exec.Command("npm", "install"))
-			runRequireNoError(t, exec.Command("npm", "run", "build"))
-		})
-	}
+func validateNodeCompiles(t *testing.T, path string) {
+	withDir(t, path, func() {
+		runRequireNoError(t, exec.Command("npm", "install"))
+		runRequireNoError(t, exec.Command("npm", "run", "build"))
+	})
+}
 
-	validateGolangCompiles := func(t *testing.T, path string) {
-		withDir(t, path, func() {
-			runRequireNoError(t, exec.Command("go", "mod", "init", "fakepackage"))
-			runRequireNoError(t, exec.Command("go", "mod", "tidy"))
-			runRequireNoError(t, exec.Command("go", "vet", "./..."))
-		})
-	}
+func validateGolangCompiles(t *testing.T, path string) {
+	withDir(t, path, func() {
+		runRequireNoError(t, exec.Command("go", "mod", "init", "fakepackage"))
+		runRequireNoError(t, exec.Command("go", "mod", "tidy"))
+		runRequireNoError(t, exec.Command("go", "vet", "./..."))
+	})
+}
 
-	validateDotnetCompiles := func(t *testing.T, path string) {
-		withDir(t, path, func() {
-			runRequireNoError(t, exec.Command("dotnet", "build"))
-		})
-	}
+func validateDotnetCompiles(t *testing.T, path string) {
+	withDir(t, path, func() {
+		runRequireNoError(t, exec.Command("dotnet", "build"))
+	})
+}
 
+// TestCRDsFromUrl pulls the CRD YAML file from a URL and generates it in each language
+func TestCRDsFromUrl(t *testing.T) {
 	// TODO(#145): Also run compilation tests for java and python.
 	compileValidationFn := map[string]func(t *testing.T, path string){
 		"nodejs": validateNodeCompiles,
@@ -284,6 +285,164 @@ func TestNodeJsObjectMeta(t *testing.T) {
 	execCrd2Pulumi(t, "nodejs", "crds/k8sversion/mock_crd.yaml", validateVersion)
 }
 
+// TestClusterScopedNamespaceNodeJs constructs a cluster-scoped resource with a namespace, which warns, or fails with
+// --strict.
+func TestClusterScopedNamespaceNodeJs(t *testing.T) {
+	const want = ".metadata.namespace: must not be set, ClusterIssuer is cluster-scoped"
+	construct := func(t *testing.T, path string) ([]byte, error) {
+		var output []byte
+		var err error
+		// enter and build the generated package
+		withDir(t, path, func() {
+			runRequireNoError(t, exec.Command("npm", "install"))
+			runRequireNoError(t, exec.Command("npm", "run", "build"))
+
+			// construct a ClusterIssuer in a namespace against mocks of the engine
+			appendFile(t, "bin/index.js", `
+require("@pulumi/pulumi").runtime.setMocks({
+    newResource: (args) => ({ id: args.name + "_id", state: args.inputs }),
+    call: (args) => args.inputs,
+});
+new certs.v1.ClusterIssuer("letsencrypt", { metadata: { namespace: "cert-manager" } });
+`)
+
+			output, err = exec.Command("node", "bin/index.js").CombinedOutput()
+		})
+		return output, err
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		execCrd2Pulumi(t, "nodejs", "crds/lookup/issuers.yaml", func(t *testing.T, path string) {
+			output, err := construct(t, path)
+			require.NoError(t, err, string(output))
+			assert.Contains(t, string(output), "warning: "+want)
+		})
+	})
+	t.Run("strict", func(t *testing.T) {
+		execCrd2Pulumi(t, "nodejs", "crds/lookup/issuers.yaml", func(t *testing.T, path string) {
+			output, err := construct(t, path)
+			require.Error(t, err, string(output))
+			assert.Contains(t, string(output), want)
+		}, "--strict")
+	})
+}
+
+// TestLookupFunctions generates every language with --lookup-functions, and checks that the lookup functions are
+// written next to the resources they read and that the packages still compile.
+func TestLookupFunctions(t *testing.T) {
+	// The files of the lookup functions of every language, or the module indexes exporting them, by name.
+	lookupFiles := map[string]map[string]string{
+		"nodejs": {
+			"getIssuer.ts":        "export function getIssuer(args: GetIssuerArgs",
+			"getClusterIssuer.ts": "ClusterIssuer is cluster-scoped, so leave the namespace unset.",
+			"index.ts":            `utilities.lazyLoad(exports, ["getIssuer"], () => require("./getIssuer"));`,
+		},
+		"python": {
+			"get_issuer.py":         "def get_issuer(name: str, namespace: Optional[str] = None",
+			"get_cluster_issuer.py": "ClusterIssuer is cluster-scoped, so leave the namespace unset.",
+			"__init__.py":           "from .get_issuer import *",
+		},
+		"go": {
+			"getIssuer.go":        "func LookupIssuer(ctx *pulumi.Context, name, namespace string",
+			"getClusterIssuer.go": "// ClusterIssuer is cluster-scoped, so leave the namespace unset.",
+		},
+		"dotnet": {
+			"GetIssuer.cs":        "public static Issuer Invoke(string name",
+			"GetClusterIssuer.cs": "ClusterIssuer is cluster-scoped, so leave the namespace unset.",
+		},
+		"java": {
+			"GetIssuer.java":        "public static Issuer invoke(java.lang.String name",
+			"GetClusterIssuer.java": "ClusterIssuer is cluster-scoped, so leave the namespace unset.",
+		},
+	}
+	compileValidationFn := map[string]func(t *testing.T, path string){
+		"nodejs": validateNodeCompiles,
+		"go":     validateGolangCompiles,
+		"dotnet": validateDotnetCompiles,
+	}
+
+	for _, lang := range languages {
+		t.Run(lang, func(t *testing.T) {
+			validate := func(t *testing.T, path string) {
+				found := map[string]bool{}
+				err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+					if err != nil || d.IsDir() {
+						return err
+					}
+					want, ok := lookupFiles[lang][d.Name()]
+					if !ok {
+						return nil
+					}
+					code, err := os.ReadFile(file)
+					require.NoError(t, err)
+					if strings.Contains(string(code), want) {
+						found[d.Name()] = true
+					}
+					return nil
+				})
+				require.NoError(t, err)
+				for name, want := range lookupFiles[lang] {
+					assert.True(t, found[name], "expected a %s file containing %q", name, want)
+				}
+				if compile := compileValidationFn[lang]; compile != nil {
+					compile(t, path)
+				}
+			}
+			execCrd2Pulumi(t, lang, "crds/lookup/issuers.yaml", validate, "--lookup-functions")
+		})
+	}
+}
+
+// TestGenerateAllLanguages generates every language from a single read of the CRDs.
+func TestGenerateAllLanguages(t *testing.T) {
+	tmpdir := t.TempDir()
+	settings := make([]*codegen.CodegenSettings, 0, len(languages))
+	for _, lang := range languages {
+		settings = append(settings, &codegen.CodegenSettings{
+			Language:       lang,
+			OutputDir:      filepath.Join(tmpdir, lang),
+			PackageName:    codegen.DefaultName,
+			PackageVersion: "1.2.3",
+		})
+	}
+
+	require.NoError(t, codegen.GenerateAllFromFiles(settings, []string{"crds/k8sversion/mock_crd.yaml"}))
+
+	for _, lang := range languages {
+		entries, err := os.ReadDir(filepath.Join(tmpdir, lang))
+		require.NoError(t, err)
+		assert.NotEmpty(t, entries, "expected %s code to be generated", lang)
+	}
+}
+
+// TestGenerateFromConfig generates the languages configured in a crd2pulumi.yaml file.
+func TestGenerateFromConfig(t *testing.T) {
+	tmpdir := t.TempDir()
+	crdPath, err := filepath.Abs("crds/k8sversion/mock_crd.yaml")
+	require.NoError(t, err)
+	configPath := filepath.Join(tmpdir, "crd2pulumi.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(fmt.Sprintf(`
+version: 1.2.3
+sources: [%q]
+languages:
+  go:
+    outputDir: sdk/go
+  schema: {}
+`, crdPath)), 0o600))
+
+	cmd := cmd.New()
+	cmd.SetArgs([]string{"generate", "-c", configPath})
+	require.NoError(t, cmd.Execute())
+
+	schema, err := os.ReadFile(filepath.Join(tmpdir, "crds", "schema", "schema.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(schema), `"version": "1.2.3"`)
+
+	entries, err := os.ReadDir(filepath.Join(tmpdir, "sdk", "go"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, entries)
+}
+
 func withDir(t *testing.T, dir string, f func()) {
 	pwd, err := os.Getwd()
 	require.NoError(t, err)
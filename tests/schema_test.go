@@ -18,18 +18,22 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/pulumi/crd2pulumi/pkg/codegen"
 	pschema "github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
 const TestCombineSchemasYAML = "test-combineschemas.yaml"
 const TestGetTypeSpecYAML = "test-gettypespec.yaml"
 const TestGetTypeSpecJSON = "test-gettypespec.json"
+const TestSchemaDocsYAML = "crds/schemadocs/listeners.yaml"
 
 // UnmarshalYaml un-marshals one and only one YAML document from a file
 func UnmarshalYaml(yamlFile []byte) (map[string]any, error) {
@@ -118,3 +122,45 @@ func TestGetTypeSpec(t *testing.T) {
 		assert.EqualValues(t, expected, actual)
 	}
 }
+
+// TestPackageSpecDocs checks the descriptions added to the package spec that every SDK is generated from.
+func TestPackageSpecDocs(t *testing.T) {
+	sources, err := codegen.OpenSources([]string{TestSchemaDocsYAML})
+	require.NoError(t, err)
+	pg, err := codegen.ReadPackagesFromSource("1.0.0", sources)
+	require.NoError(t, err)
+	pkgSpec, err := pg.PackageSpec()
+	require.NoError(t, err)
+
+	listener := pkgSpec.Resources["kubernetes:networking.example.com/v1:Listener"]
+	assert.Contains(t, listener.Description, "Validation rules:\n- `self.metadata.name.startsWith('listener-')`: names must start with listener-")
+
+	spec := pkgSpec.Types["kubernetes:networking.example.com/v1:ListenerSpec"]
+	assert.Contains(t, spec.Description, "Validation rules:\n- `has(self.tls) == has(self.port)`: `'tls requires a port'`")
+	assert.Equal(t, "Port to listen on.\n\nValidation rules:\n- `self != 22`: port 22 is reserved", spec.Properties["port"].Description)
+	assert.Contains(t, spec.Properties["hostnames"].Description,
+		"Server-side apply merges the items of this list as a set, so a Patch adds its items and keeps the others.")
+	assert.Contains(t, spec.Properties["addresses"].Description,
+		"Server-side apply replaces this list as a whole, as it has no `x-kubernetes-list-type`, so a Patch must list every item.")
+	assert.Contains(t, spec.Properties["ports"].Description, "Server-side apply merges the items of this list by their "+
+		"`port` and `protocol` keys, so a Patch updates the items with the same keys, adds new ones and keeps the others.")
+
+	tls := pkgSpec.Types["kubernetes:networking.example.com/v1:ListenerSpecTls"]
+	assert.Contains(t, tls.Description, "Server-side apply replaces this object as a whole, so a Patch must set every field.")
+
+	ports := pkgSpec.Types["kubernetes:networking.example.com/v1:ListenerSpecPorts"]
+	assert.Equal(t, "#/types/kubernetes:networking.example.com/v1:ListenerSpecPortsProtocol", ports.Properties["protocol"].Ref)
+	assert.Len(t, pkgSpec.Types["kubernetes:networking.example.com/v1:ListenerSpecPortsProtocol"].Enum, 2)
+
+	template := pkgSpec.Types["kubernetes:networking.example.com/v1:ListenerSpecTemplate"]
+	assert.Equal(t, "#/types/kubernetes:networking.example.com/v1:ListenerSpecTemplate", spec.Properties["template"].Ref)
+	assert.ElementsMatch(t, []string{"apiVersion", "kind", "metadata", "replicas", "spec"}, slices.Collect(maps.Keys(template.Properties)))
+	assert.Equal(t, "#/types/kubernetes:meta/v1:ObjectMeta", template.Properties["metadata"].Ref)
+
+	patch := pkgSpec.Resources["kubernetes:networking.example.com/v1:ListenerPatch"]
+	assert.Contains(t, patch.Description, "Server-side apply merge semantics:\n"+
+		"- `spec.addresses`: replaced as a whole, as it has no list type\n"+
+		"- `spec.hostnames`: items merged as a set\n"+
+		"- `spec.ports`: items merged by `port` and `protocol`\n"+
+		"- `spec.tls`: replaced as a whole")
+}